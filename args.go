@@ -0,0 +1,39 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// parseFlags splits command arguments into positional arguments and
+// --key=value flags. A bare --key is stored as "true".
+func parseFlags(args []string) ([]string, map[string]string) {
+	return parseValueFlags(args)
+}
+
+// parseValueFlags is parseFlags where the flags named in valued take
+// their value from the next argument when written without =, as in
+// --where 'type=fire'
+func parseValueFlags(args []string, valued ...string) ([]string, map[string]string) {
+	positional := []string{}
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		name, ok := strings.CutPrefix(args[i], "--")
+		if !ok || name == "" {
+			positional = append(positional, args[i])
+			continue
+		}
+
+		key, val, found := strings.Cut(name, "=")
+		switch {
+		case found:
+		case slices.Contains(valued, key) && i+1 < len(args):
+			i++
+			val = args[i]
+		default:
+			val = "true"
+		}
+		flags[key] = val
+	}
+	return positional, flags
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nurusanwe/pokedexcli/internal/battle"
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// errInBattle is returned by commands that can't be used mid-battle
+var errInBattle = errors.New("you are in a battle, use attack or run first")
+
+// trainerClasses are the opponents met with battle --trainer
+var trainerClasses = []string{"Youngster", "Lass", "Bug Catcher", "Hiker", "Swimmer", "Fisherman", "Ace Trainer"}
+
+// battleState is the battle in progress. foe is the opposing
+// individual, which can be caught when it is wild.
+type battleState struct {
+	engine  *battle.Battle
+	mine    *owned.Pokemon
+	foe     owned.Pokemon
+	trainer string
+}
+
+// knownMoves returns the moves a Pokemon knows at a level when it
+// doesn't remember any: the last level-up moves it learned
+func knownMoves(pokemon pokeapi.PokemonDetails, level int) []string {
+	moves := []string{}
+	for _, entry := range learnset(pokemon, latestVersionGroup(pokemon), "level-up") {
+		if entry.Level > level {
+			break
+		}
+		known := false
+		for _, m := range moves {
+			known = known || m == entry.Move
+		}
+		if !known {
+			moves = append(moves, entry.Move)
+		}
+	}
+	return moves[max(len(moves)-battle.MaxMoves, 0):]
+}
+
+// newCombatant fetches what a Pokemon needs to fight: its types, its
+// stats at its level and its moves
+func newCombatant(conf *config, name string, p *owned.Pokemon) (*battle.Combatant, error) {
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(p.Species)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Moves) == 0 {
+		p.Moves = knownMoves(pokemon, p.Level)
+	}
+
+	fetched, err := pokeapi.FetchConcurrently(p.Moves, moveWorkers, conf.pokeapiClient.FetchMove)
+	if err != nil {
+		return nil, err
+	}
+	moves := []battle.Move{}
+	for _, m := range p.Moves {
+		moves = append(moves, battle.NewMove(fetched[m]))
+	}
+
+	types := []string{}
+	for _, t := range pokemon.Types {
+		types = append(types, t.Type.Name)
+	}
+	return battle.NewCombatant(name, p.Level, types, p.Stats(owned.BaseStats(pokemon)), moves), nil
+}
+
+// startBattle sends mine out against foe
+func startBattle(conf *config, mine *owned.Pokemon, foe owned.Pokemon, trainer string) error {
+	foeName := "wild " + foe.Species
+	if trainer != "" {
+		foeName = "foe " + foe.Species
+	}
+	foeCombatant, err := newCombatant(conf, foeName, &foe)
+	if err != nil {
+		return err
+	}
+	myCombatant, err := newCombatant(conf, mine.Name(), mine)
+	if err != nil {
+		return err
+	}
+	chart, err := loadTypeChart(conf)
+	if err != nil {
+		return err
+	}
+
+	if err := markSeen(conf, foe.Species); err != nil {
+		return err
+	}
+
+	conf.battle = &battleState{
+		engine:  battle.New(conf.rng, chart, myCombatant, foeCombatant),
+		mine:    mine,
+		foe:     foe,
+		trainer: trainer,
+	}
+	if trainer != "" {
+		fmt.Printf("%s wants to battle!\n", trainer)
+		fmt.Printf("%s sent out %s (Lv. %d)!\n", trainer, foe.Species, foe.Level)
+	}
+	fmt.Printf("Go, %s!\n", mine.Name())
+	printBattleStatus(conf.battle)
+	return nil
+}
+
+// trainerPokemon generates the Pokemon of a trainer met in an area:
+// a species found there near the level of the player's Pokemon
+func trainerPokemon(conf *config, level int) (owned.Pokemon, error) {
+	if conf.location == "" {
+		return owned.Pokemon{}, errors.New("you aren't anywhere yet, use goto <area> first")
+	}
+	area, err := conf.pokeapiClient.ListExplore(conf.location)
+	if err != nil {
+		return owned.Pokemon{}, err
+	}
+	if len(area.PokemonEncounters) == 0 {
+		return owned.Pokemon{}, fmt.Errorf("there are no trainers around %s", area.Name)
+	}
+
+	name := area.PokemonEncounters[conf.rng.Intn(len(area.PokemonEncounters))].Pokemon.Name
+	return newFoe(conf, name, level-2+conf.rng.Intn(4))
+}
+
+// newFoe generates an individual to battle. Forms such as
+// wormadam-plant get the gender rate of their species.
+func newFoe(conf *config, name string, level int) (owned.Pokemon, error) {
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(name)
+	if err != nil {
+		return owned.Pokemon{}, err
+	}
+	species, err := conf.pokeapiClient.FetchPokemonSpecies(pokemon.Species.Name)
+	if err != nil {
+		return owned.Pokemon{}, err
+	}
+	return owned.New(conf.rng, 0, name, level, species.GenderRate), nil
+}
+
+// printBattleStatus shows both sides and the moves to pick from
+func printBattleStatus(state *battleState) {
+	for _, c := range []*battle.Combatant{state.engine.B, state.engine.A} {
+		fmt.Printf("%s Lv. %d: %d/%d HP\n", c.Name, c.Level, c.HP, c.Stats.HP)
+	}
+	fmt.Println("Moves:")
+	for i, m := range state.engine.A.Moves {
+		fmt.Printf(" %d. %s (%s) %d/%d PP\n", i+1, m.Name, m.Type, m.PP, m.MaxPP)
+	}
+}
+
+// printBattleEvent narrates one move
+func printBattleEvent(e battle.Event, defender *battle.Combatant) {
+	fmt.Printf("%s used %s!\n", e.Attacker, e.Move)
+	switch {
+	case e.Missed:
+		fmt.Printf("%s avoided the attack!\n", e.Defender)
+		return
+	case e.Effectiveness == 0:
+		fmt.Printf("It doesn't affect %s...\n", e.Defender)
+		return
+	case e.Damage == 0:
+		return
+	}
+
+	if e.Critical {
+		fmt.Println("A critical hit!")
+	}
+	switch {
+	case e.Effectiveness > 1:
+		fmt.Println("It's super effective!")
+	case e.Effectiveness < 1:
+		fmt.Println("It's not very effective...")
+	}
+	fmt.Printf("%s lost %d HP (%d/%d)\n", e.Defender, e.Damage, defender.HP, defender.Stats.HP)
+	if e.Recoil > 0 {
+		fmt.Printf("%s is damaged by recoil!\n", e.Attacker)
+	}
+	if e.Fainted {
+		fmt.Printf("%s fainted!\n", e.Defender)
+	}
+	if e.AttackerDown {
+		fmt.Printf("%s fainted!\n", e.Attacker)
+	}
+}
+
+// chooseMove resolves the move the player picked by name or number, or
+// Struggle when no move has PP left
+func chooseMove(c *battle.Combatant, ref string) (int, error) {
+	if len(c.UsableMoves()) == 0 {
+		fmt.Printf("%s has no moves left!\n", c.Name)
+		return -1, nil
+	}
+	if ref == "" {
+		return 0, errors.New("you must provide a move name or number")
+	}
+	for i, m := range c.Moves {
+		if m.Name == ref || strconv.Itoa(i+1) == ref {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%s doesn't know %s", c.Name, ref)
+}
+
+func commandBattle(conf *config, args ...string) error {
+	args, flags := parseFlags(args)
+	if conf.battle != nil {
+		if len(args) > 0 || len(flags) > 0 {
+			return errInBattle
+		}
+		printBattleStatus(conf.battle)
+		return nil
+	}
+	if len(args) > 1 {
+		return errors.New("you can only send out one pokemon")
+	}
+
+	var mine *owned.Pokemon
+	if len(args) == 1 {
+		if mine = resolveOwned(conf, args[0]); mine == nil {
+			return nil
+		}
+		if place, _ := conf.storage.Find(mine.ID); place.Box != 0 {
+			return fmt.Errorf("%s is in box %d, withdraw it first", mine.Name(), place.Box)
+		}
+	} else if len(conf.storage.Party) > 0 {
+		mine = conf.storage.Party[0]
+	} else {
+		return errors.New("you have no pokemon to battle with, catch one first")
+	}
+
+	if flags["trainer"] == "true" {
+		foe, err := trainerPokemon(conf, mine.Level)
+		if err != nil {
+			return err
+		}
+		trainer := trainerClasses[conf.rng.Intn(len(trainerClasses))]
+		return startBattle(conf, mine, foe, trainer)
+	}
+
+	if conf.wild == nil {
+		return errors.New("there is no wild pokemon around, try walk, surf or fish first, or battle --trainer")
+	}
+	foe, err := newFoe(conf, conf.wild.Name, conf.wild.Level)
+	if err != nil {
+		return err
+	}
+	return startBattle(conf, mine, foe, "")
+}
+
+func commandAttack(conf *config, args ...string) error {
+	state := conf.battle
+	if state == nil {
+		return errors.New("you aren't in a battle, use battle first")
+	}
+
+	engine := state.engine
+	move, err := chooseMove(engine.A, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	events, err := engine.Turn(move, engine.RandomMove(engine.B))
+	if err != nil {
+		return err
+	}
+	return finishTurn(conf, events)
+}
+
+// finishTurn narrates the events of a turn, then ends the battle and
+// hands out the rewards once either side has fainted
+func finishTurn(conf *config, events []battle.Event) error {
+	state := conf.battle
+	engine := state.engine
+	for _, e := range events {
+		defender := engine.B
+		if e.Defender == engine.A.Name {
+			defender = engine.A
+		}
+		printBattleEvent(e, defender)
+	}
+
+	switch engine.Winner() {
+	case nil:
+		printBattleStatus(state)
+		return nil
+	case engine.A:
+		if state.trainer != "" {
+			prize := prizePerLevel * state.foe.Level
+			conf.bag.Earn(prize)
+			fmt.Printf("You defeated %s!\n", state.trainer)
+			fmt.Printf("You got ₽%d for winning!\n", prize)
+		} else {
+			fmt.Printf("You defeated the wild %s!\n", state.foe.Species)
+		}
+	default:
+		fmt.Printf("%s can't battle anymore, you lost the battle\n", state.mine.Name())
+	}
+	conf.battle = nil
+	if state.trainer == "" {
+		conf.wild = nil
+	}
+	if engine.Winner() == engine.A {
+		return awardExperience(conf, state.mine, state.foe, state.trainer != "")
+	}
+	return nil
+}
+
+func commandRun(conf *config, args ...string) error {
+	if conf.battle == nil {
+		return errors.New("you aren't in a battle")
+	}
+	if conf.battle.trainer != "" {
+		fmt.Printf("You forfeited the battle against %s\n", conf.battle.trainer)
+	} else {
+		fmt.Println("Got away safely!")
+		conf.wild = nil
+	}
+	conf.battle = nil
+	return nil
+}
@@ -0,0 +1,35 @@
+// Command fakepokeapi serves a small embedded PokéAPI dataset so the
+// CLI can be developed and tested without network access:
+//
+//	go run ./cmd/fakepokeapi -addr localhost:8080 -latency 200ms -error-rate 0.1
+//	POKEAPI_BASE_URL=http://localhost:8080/api/v2 go run .
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi/pokeapitest"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	latency := flag.Duration("latency", 0, "delay added to every response")
+	errorRate := flag.Float64("error-rate", 0, "probability (0-1) of answering with a 500")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for error injection")
+	flag.Parse()
+
+	handler, err := pokeapitest.NewHandler(pokeapitest.Options{
+		Latency:   *latency,
+		ErrorRate: *errorRate,
+		Seed:      *seed,
+	})
+	if err != nil {
+		log.Fatalf("failed to load dataset: %v", err)
+	}
+
+	log.Printf("Serving the fake PokéAPI on http://%s/api/v2", *addr)
+	log.Fatal(http.ListenAndServe(*addr, handler))
+}
@@ -3,13 +3,20 @@ package main
 import (
 	"errors"
 	"fmt"
-	"math/rand"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/nurusanwe/pokedexcli/internal/catch"
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
 )
 
 func commandExit(conf *config, args ...string) error {
 	fmt.Println("Closing the Pokedex... Goodbye!")
+	saveGame(conf)
+	saveCache(conf)
 	os.Exit(0)
 	return nil
 }
@@ -24,6 +31,16 @@ func commandHelp(conf *config, args ...string) error {
 }
 
 func commandMap(conf *config, args ...string) error {
+	_, flags := parseFlags(args)
+	if region, ok := flags["region"]; ok {
+		if err := selectMapRegion(conf, region); err != nil {
+			return err
+		}
+	}
+	if conf.mapRegion != "" {
+		return printRegionPage(conf, conf.regionPage+1)
+	}
+
 	locationsResp, err := conf.pokeapiClient.ListLocations(conf.nextLocationsURL)
 	if err != nil {
 		return err
@@ -39,6 +56,9 @@ func commandMap(conf *config, args ...string) error {
 }
 
 func commandMapBack(conf *config, args ...string) error {
+	if conf.mapRegion != "" {
+		return printRegionPage(conf, conf.regionPage-1)
+	}
 	if conf.prevLocationsURL == nil {
 		return errors.New("you're on the first page")
 	}
@@ -58,99 +78,986 @@ func commandMapBack(conf *config, args ...string) error {
 }
 
 func commandExplore(cfg *config, args ...string) error {
+	args, flags := parseFlags(args)
 	if len(args) != 1 {
 		return errors.New("you must provide a unique location name")
 	}
 
+	version := flags["version"]
+	method := flags["method"]
+
 	name := args[0]
 	location, err := cfg.pokeapiClient.ListExplore(name)
 	if err != nil {
-		return err
+		return suggestNotFound(cfg, "location-area", name, err)
 	}
 	fmt.Printf("Exploring %s...\n", location.Name)
-	fmt.Println("Found Pokemon: ")
+
+	rates := []string{}
+	for _, rate := range location.EncounterMethodRates {
+		if method != "" && rate.EncounterMethod.Name != method {
+			continue
+		}
+		for _, detail := range rate.VersionDetails {
+			if version != "" && detail.Version.Name != version {
+				continue
+			}
+			rates = append(rates, fmt.Sprintf("%s %d%% (%s)", rate.EncounterMethod.Name, detail.Rate, detail.Version.Name))
+		}
+	}
+	if len(rates) > 0 {
+		fmt.Printf("Encounter rates: %s\n", strings.Join(rates, ", "))
+	}
+
+	rows := [][]string{}
+	seen := []string{}
 	for _, enc := range location.PokemonEncounters {
-		fmt.Printf(" - %s\n", enc.Pokemon.Name)
+		for _, detail := range enc.VersionDetails {
+			if version != "" && detail.Version.Name != version {
+				continue
+			}
+			for _, s := range summarizeEncounters(detail.EncounterDetails) {
+				if method != "" && s.Method != method {
+					continue
+				}
+				if !slices.Contains(seen, enc.Pokemon.Name) {
+					seen = append(seen, enc.Pokemon.Name)
+				}
+				rows = append(rows, []string{
+					enc.Pokemon.Name, detail.Version.Name, s.Method,
+					levelRange(s.MinLevel, s.MaxLevel), fmt.Sprintf("%d%%", s.Chance),
+					describeConditions(s.Conditions),
+				})
+			}
+		}
+	}
+	if len(rows) == 0 {
+		fmt.Println("No Pokemon found")
+		return nil
+	}
+	// The encounters are listed even if some pokemon can't be fetched,
+	// those are only left unseen
+	markSeen(cfg, seen...)
+
+	fmt.Printf("%-12s %-9s %-10s %-7s %-6s %s\n", "Pokemon", "Version", "Method", "Levels", "Chance", "Conditions")
+	for _, row := range rows {
+		line := fmt.Sprintf("%-12s %-9s %-10s %-7s %-6s %s", row[0], row[1], row[2], row[3], row[4], row[5])
+		fmt.Println(strings.TrimRight(line, " "))
 	}
 	return nil
 }
 
 func commandCatch(conf *config, args ...string) error {
+	args, flags := parseFlags(args)
 	if len(args) > 1 {
 		return errors.New("you can only catch one Pokemon at a time")
 	}
 
+	freeCatch := flags["free-catch"] == "true"
+	if len(args) == 0 && conf.wild != nil && !freeCatch {
+		args = []string{conf.wild.Name}
+	}
+
 	if len(args) == 0 {
-		if len(conf.caughtPokemon) == 0 {
+		if len(conf.storage.All()) == 0 {
 			fmt.Println("No Pokemon caught yet.")
 			return nil
 		}
 		fmt.Println("Caught Pokemon:")
-		for name := range conf.caughtPokemon {
-			fmt.Println(" -", name)
+		for _, p := range conf.storage.All() {
+			fmt.Println(" -", describeOwned(p))
 		}
 		return nil
 	}
 
 	pokemonName := strings.ToLower(args[0])
+	if conf.battle != nil && conf.battle.trainer != "" {
+		return errors.New("you can't catch a trainer's pokemon")
+	}
+	if !freeCatch {
+		if conf.wild == nil {
+			return errors.New("there is no wild pokemon around, try walk, surf or fish first")
+		}
+		if pokemonName != conf.wild.Name {
+			return fmt.Errorf("there is no wild %s here, you are facing a wild %s", pokemonName, conf.wild.Name)
+		}
+	}
 
-	if _, exists := conf.caughtPokemon[pokemonName]; exists {
-		fmt.Printf("%s has already been caught!\n", pokemonName)
-		return nil
+	// Free catches have no encounter to take the level from
+	level := 5
+	if conf.wild != nil && !freeCatch {
+		level = conf.wild.Level
+	} else if l, ok := flags["level"]; ok {
+		var err error
+		level, err = strconv.Atoi(l)
+		if err != nil || level < 1 || level > owned.MaxLevel {
+			return fmt.Errorf("invalid level %q, expected a number between 1 and %d", l, owned.MaxLevel)
+		}
+	}
+
+	// Wild Pokemon are at full health unless told otherwise or worn
+	// down in battle
+	hpPercent := 100
+	if conf.battle != nil && !freeCatch {
+		foe := conf.battle.engine.B
+		hpPercent = max(foe.HP*100/foe.Stats.HP, 1)
+	} else if hp, ok := flags["hp"]; ok {
+		var err error
+		hpPercent, err = strconv.Atoi(strings.TrimSuffix(hp, "%"))
+		if err != nil || hpPercent < 1 || hpPercent > 100 {
+			return fmt.Errorf("invalid hp %q, expected a percentage between 1 and 100", hp)
+		}
+	}
+
+	if conf.storage.Full() {
+		return owned.ErrStorageFull
+	}
+
+	attempt := catch.Attempt{
+		MaxHP:     100,
+		CurrentHP: hpPercent,
+		Ball:      flags["ball"],
+		Status:    flags["status"],
+	}
+	if err := attempt.Validate(); err != nil {
+		return err
 	}
 
 	pokemonDetails, err := conf.pokeapiClient.FetchPokemonDetails(pokemonName)
+	if err != nil {
+		return suggestNotFound(conf, "pokemon", pokemonName, err)
+	}
+	species, err := conf.pokeapiClient.FetchPokemonSpecies(pokemonDetails.Species.Name)
+	if err != nil {
+		return err
+	}
+	attempt.CaptureRate = species.CaptureRate
+	// Everything that can fail is fetched before the ball is thrown, so
+	// a caught pokemon is never lost
+	rate, err := conf.pokeapiClient.FetchGrowthRate(species.GrowthRate.Name)
 	if err != nil {
 		return err
 	}
 
-	// Define a fixed threshold
-	catchThreshold := pokemonDetails.BaseExperience / 2
-	// Generate one random number, no Seed setup needed anymore since go 1.20
-	catchAttempt := rand.Intn(pokemonDetails.BaseExperience)
+	ball := attempt.Ball
+	if ball == "" {
+		ball = "poke-ball"
+	}
+	// Free catches don't need a ball from the bag
+	if !freeCatch {
+		if conf.bag.Count(ball) == 0 && slices.Contains(martStock, ball) {
+			return fmt.Errorf("you have no %s left, buy some with shop buy %s", ball, ball)
+		}
+		if conf.bag.Count(ball) == 0 {
+			return fmt.Errorf("you have no %s", ball)
+		}
+		if err := conf.bag.Remove(ball, 1); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Throwing a %s at %s...\n", ball, pokemonDetails.Name)
 
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonDetails.Name)
-	if catchAttempt <= catchThreshold {
-		fmt.Printf("%s was caught!\n", pokemonDetails.Name)
-		conf.caughtPokemon[pokemonDetails.Name] = pokemonDetails
+	result := catch.Throw(conf.rng, attempt)
+	for i := 1; i <= result.Shakes && i < catch.Shakes; i++ {
+		fmt.Println(strings.Repeat("...", i) + "wobble")
+	}
+	if result.Caught {
+		fmt.Printf("Gotcha! %s was caught!\n", pokemonDetails.Name)
+		caught := owned.New(conf.rng, 0, pokemonDetails.Name, level, species.GenderRate)
+		caught.Moves = knownMoves(pokemonDetails, level)
+		// Catching earns experience for the Pokemon in battle, or else
+		// the one leading the party
+		var earner *owned.Pokemon
+		if len(conf.storage.Party) > 0 && !freeCatch {
+			earner = conf.storage.Party[0]
+		}
+		if conf.battle != nil && !freeCatch {
+			caught, earner = conf.battle.foe, conf.battle.mine
+			conf.battle = nil
+		}
+		caught.Exp = rate.Experience(caught.Level)
+		caught.CaughtAt = conf.location
+		caught.CaughtTime = conf.clock()
+		stored, place, err := conf.storage.Add(caught)
+		if err != nil {
+			return err
+		}
+		conf.dex.MarkCaught(pokemonDetails.Species.Name)
+		if place.Box == 0 {
+			fmt.Printf("%s was added to your party\n", describeOwned(stored))
+		} else {
+			fmt.Printf("%s was sent to box %d\n", describeOwned(stored), place.Box)
+		}
+		conf.wild = nil
+		if earner != nil {
+			if err := awardExperience(conf, earner, caught, false); err != nil {
+				return err
+			}
+		}
 	} else {
 		fmt.Printf("%s escaped!\n", pokemonDetails.Name)
+		// Throwing a ball takes the turn, the foe attacks meanwhile
+		if conf.battle != nil && !freeCatch {
+			engine := conf.battle.engine
+			events, err := engine.FoeTurn(engine.RandomMove(engine.B))
+			if err != nil {
+				return err
+			}
+			return finishTurn(conf, events)
+		}
 	}
 
 	return nil
 }
 
 func commandInspect(conf *config, args ...string) error {
-
+	args, flags := parseFlags(args)
 	if len(args) != 1 {
 		return errors.New("you must provide a pokemon name")
 	}
 
-	name := strings.ToLower(args[0])
-	if _, exists := conf.caughtPokemon[name]; !exists {
-		fmt.Println("you have not caught that pokemon")
+	p := resolveOwned(conf, args[0])
+	if p == nil {
 		return nil
 	}
 
-	pokemon := conf.caughtPokemon[name]
-	fmt.Printf("Name: %s\n", pokemon.Name)
+	version := flags["version"]
+
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(p.Species)
+	if err != nil {
+		return err
+	}
+	// The species only adds the genus, EXP and Pokedex entry, the
+	// Pokemon is shown without them when it can't be fetched
+	species, speciesErr := conf.pokeapiClient.FetchPokemonSpecies(pokemon.Species.Name)
+
+	fmt.Printf("Name: %s\n", p.Name())
+	if p.Nickname != "" {
+		fmt.Printf("Species: %s\n", p.Species)
+	}
+	fmt.Printf("ID: #%d\n", p.ID)
+	if genus := species.Genus(conf.language); speciesErr == nil && genus != "" {
+		fmt.Printf("Genus: %s\n", genus)
+	}
+	fmt.Printf("Level: %d\n", p.Level)
+	if speciesErr == nil {
+		if rate, err := conf.pokeapiClient.FetchGrowthRate(species.GrowthRate.Name); err == nil {
+			fmt.Printf("Exp: %s\n", describeExperience(p, rate))
+		}
+	}
+	nature, _ := owned.NatureByName(p.Nature)
+	if nature.Neutral() {
+		fmt.Printf("Nature: %s\n", p.Nature)
+	} else {
+		fmt.Printf("Nature: %s (+%s, -%s)\n", p.Nature, nature.Increased, nature.Decreased)
+	}
+	fmt.Printf("Gender: %s\n", p.Gender)
+	if p.Shiny {
+		fmt.Println("Shiny: yes ★")
+	}
+	if p.CaughtAt != "" {
+		fmt.Printf("Caught: at %s on %s\n", p.CaughtAt, p.CaughtTime.Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("Caught: on %s\n", p.CaughtTime.Format("2006-01-02 15:04"))
+	}
 	fmt.Printf("Height: %d\n", pokemon.Height)
 	fmt.Printf("Weight: %d\n", pokemon.Weight)
+
+	base := owned.BaseStats(pokemon)
+	stats := p.Stats(base)
 	fmt.Println("Stats:")
-	for _, stat := range pokemon.Stats {
-		fmt.Printf(" - %s: %d\n", stat.Stat.Name, stat.BaseStat)
+	for _, stat := range owned.StatNames {
+		fmt.Printf(" - %s: %d (base %d, IV %d, EV %d)\n",
+			stat, stats.Get(stat), base.Get(stat), p.IVs.Get(stat), p.EVs.Get(stat))
 	}
 	fmt.Println("Types:")
 	for _, t := range pokemon.Types {
 		fmt.Printf(" - %s\n", t.Type.Name)
 	}
+	fmt.Println("Abilities:")
+	for _, a := range pokemon.Abilities {
+		if a.IsHidden {
+			fmt.Printf(" - %s (hidden)\n", a.Ability.Name)
+		} else {
+			fmt.Printf(" - %s\n", a.Ability.Name)
+		}
+	}
+	if len(p.Moves) > 0 {
+		fmt.Printf("Moves: %s\n", strings.Join(p.Moves, ", "))
+	}
+
+	if speciesErr != nil {
+		fmt.Println("No Pokedex entry:", speciesErr)
+		return nil
+	}
+	text, entryVersion, found := species.FlavorText(version, conf.language)
+	if !found {
+		fmt.Printf("No Pokedex entry for version %q\n", version)
+		return nil
+	}
+	fmt.Printf("Pokedex entry (%s):\n", entryVersion)
+	fmt.Printf("  %s\n", text)
+	return nil
+}
+
+func commandPrefetch(conf *config, args ...string) error {
+	opts := pokeapi.DefaultPrefetchOptions()
+	opts.Progress = func(stage string, done, total int) {
+		fmt.Printf("\rFetching %s: %d/%d", stage, done, total)
+		if done == total {
+			fmt.Println()
+		}
+	}
+
+	fmt.Println("Prefetching location areas, pokemon and types, this may take a while...")
+	stats, err := conf.pokeapiClient.Prefetch(opts)
+	fmt.Printf("Cached %d pages, %d areas, %d pokemon, %d species, %d growth rates and %d types (%d failed)\n",
+		stats.Pages, stats.Areas, stats.Pokemon, stats.Species, stats.GrowthRates, stats.Types, stats.Failed)
+
+	// Keep whatever was fetched even if some requests failed
+	saveCache(conf)
+	return err
+}
+
+func commandCache(conf *config, args ...string) error {
+	stats := conf.pokeapiClient.CacheStats()
+	fmt.Printf("Entries: %d (%d compressed)\n", stats.Entries, stats.Compressed)
+	fmt.Printf("Raw size: %.1f KiB\n", float64(stats.RawBytes)/1024)
+	fmt.Printf("Stored size: %.1f KiB\n", float64(stats.StoredBytes)/1024)
+	fmt.Printf("Compression ratio: %.1f%%\n", stats.Ratio()*100)
+	return nil
+}
+
+func commandLanguage(conf *config, args ...string) error {
+	if len(args) > 1 {
+		return errors.New("you can only choose one language")
+	}
+
+	if len(args) == 0 {
+		fmt.Printf("Language: %s\n", conf.language)
+		return nil
+	}
+
+	conf.language = args[0]
+	fmt.Printf("Descriptions will now be in %s\n", conf.language)
+	return nil
+}
+
+func commandEvolution(conf *config, args ...string) error {
+	if len(args) != 1 {
+		return errors.New("you must provide a pokemon name")
+	}
+
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(args[0])
+	if err != nil {
+		return err
+	}
+	chain, err := conf.pokeapiClient.FetchSpeciesEvolutionChain(pokemon.Species.Name)
+	if err != nil {
+		return err
+	}
+
+	printEvolutionTree(chain.Chain, "", true, true)
+	return nil
+}
+
+func commandEvolve(conf *config, args ...string) error {
+	if conf.battle != nil {
+		return errInBattle
+	}
+	args, flags := parseFlags(args)
+	if len(args) != 1 {
+		return errors.New("you must provide a pokemon name")
+	}
+
+	p := resolveOwned(conf, args[0])
+	if p == nil {
+		return nil
+	}
+	item := flags["item"]
+	if item != "" && conf.bag.Count(item) == 0 {
+		return fmt.Errorf("you have no %s", item)
+	}
+
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(p.Species)
+	if err != nil {
+		return err
+	}
+	species, err := conf.pokeapiClient.FetchPokemonSpecies(pokemon.Species.Name)
+	if err != nil {
+		return err
+	}
+	chain, err := conf.pokeapiClient.FetchSpeciesEvolutionChain(pokemon.Species.Name)
+	if err != nil {
+		return err
+	}
+	link, found := chain.Chain.Find(species.Name)
+	if !found || len(link.EvolvesTo) == 0 {
+		fmt.Printf("%s does not evolve\n", p.Name())
+		return nil
+	}
+
+	// Friendship isn't tracked, so it stays the species' base friendship
+	state := evolutionState{
+		Level:     p.Level,
+		Gender:    p.Gender,
+		Happiness: species.BaseHappiness,
+		UsedItem:  item,
+		Traded:    flags["trade"] == "true",
+		Clock:     conf.clock(),
+	}
+	into, reasons := findEvolution(link, state, flags["into"])
+	if into == "" {
+		if len(reasons) == 0 {
+			return fmt.Errorf("%s can't evolve into %s", p.Name(), flags["into"])
+		}
+		fmt.Printf("%s can't evolve yet:\n", p.Name())
+		for _, reason := range reasons {
+			fmt.Printf(" - %s\n", reason)
+		}
+		return nil
+	}
+
+	if err := evolveOwned(conf, p, into); err != nil {
+		return err
+	}
+	// Only use-item evolutions accept an item, which is used up
+	if item != "" {
+		return conf.bag.Remove(item, 1)
+	}
+	return nil
+}
+
+func commandTypes(conf *config, args ...string) error {
+	chart, err := loadTypeChart(conf)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Attacking type (rows) against defending type (columns):")
+	fmt.Printf("%-9s", "")
+	for _, defender := range chart.Types() {
+		fmt.Printf("%4s", defender[:3])
+	}
+	fmt.Println()
+	for _, attack := range chart.Types() {
+		fmt.Printf("%-9s", attack)
+		for _, defender := range chart.Types() {
+			fmt.Printf("%4s", formatMultiplier(chart.Multiplier(attack, defender)))
+		}
+		fmt.Println()
+	}
+	fmt.Println("2 = super effective, ½ = not very effective, 0 = no effect, · = normal")
 	return nil
 }
 
-func commandPokedex(conf *config, args ...string) error {
-	fmt.Println("Your Pokedex:")
-	for name := range conf.caughtPokemon {
-		fmt.Println(" -", name)
+func commandWeak(conf *config, args ...string) error {
+	if len(args) != 1 {
+		return errors.New("you must provide a pokemon name")
+	}
+
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(args[0])
+	if err != nil {
+		return err
+	}
+	chart, err := loadTypeChart(conf)
+	if err != nil {
+		return err
+	}
+
+	types := []string{}
+	for _, t := range pokemon.Types {
+		types = append(types, t.Type.Name)
+	}
+	matchup := chart.Defend(types...)
+
+	fmt.Printf("%s (%s)\n", pokemon.Name, strings.Join(types, "/"))
+	fmt.Println("Weaknesses:", orNone(sortedMultipliers(matchup.Weaknesses)))
+	fmt.Println("Resistances:", orNone(sortedMultipliers(matchup.Resistances)))
+	fmt.Println("Immunities:", orNone(strings.Join(matchup.Immunities, ", ")))
+	return nil
+}
+
+// orNone replaces an empty list with "none"
+func orNone(list string) string {
+	if list == "" {
+		return "none"
+	}
+	return list
+}
+
+func commandMoves(conf *config, args ...string) error {
+	args, flags := parseFlags(args)
+	if len(args) != 1 {
+		return errors.New("you must provide a pokemon name")
+	}
+
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(args[0])
+	if err != nil {
+		return err
+	}
+
+	versionGroup := flags["version-group"]
+	if versionGroup == "" {
+		versionGroup = latestVersionGroup(pokemon)
+	}
+	entries := learnset(pokemon, versionGroup, flags["method"])
+	if len(entries) == 0 {
+		fmt.Printf("%s learns no moves in %s\n", pokemon.Name, versionGroup)
+		return nil
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Move)
+	}
+	moves, err := pokeapi.FetchConcurrently(names, moveWorkers, conf.pokeapiClient.FetchMove)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Moves of %s in %s:\n", pokemon.Name, versionGroup)
+	fmt.Printf("%-5s %-16s %-9s %-9s %5s %5s %4s  %-9s %s\n", "Lvl", "Move", "Type", "Class", "Power", "Acc", "PP", "Method", "Effect")
+	for _, entry := range entries {
+		move := moves[entry.Move]
+		level := "-"
+		if entry.Method == "level-up" {
+			level = strconv.Itoa(entry.Level)
+		}
+		line := fmt.Sprintf("%-5s %-16s %-9s %-9s %5s %5s %4s  %-9s %s",
+			level, entry.Move, move.Type.Name, move.DamageClass.Name,
+			optionalInt(move.Power), optionalInt(move.Accuracy), optionalInt(move.PP), entry.Method,
+			move.ShortEffect(conf.language))
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	return nil
+}
+
+func commandAbility(conf *config, args ...string) error {
+	if len(args) != 1 {
+		return errors.New("you must provide an ability name")
+	}
+
+	ability, err := conf.pokeapiClient.FetchAbility(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (%s)\n", ability.Name, ability.Generation.Name)
+	if effect, short, found := ability.Effect(conf.language); found {
+		fmt.Printf("  %s\n", short)
+		if effect != short {
+			fmt.Printf("  %s\n", effect)
+		}
+	} else {
+		fmt.Printf("  No description in language %q\n", conf.language)
+	}
+
+	fmt.Println("Pokemon with this ability:")
+	for _, p := range ability.Pokemon {
+		if p.IsHidden {
+			fmt.Printf(" - %s (hidden)\n", p.Pokemon.Name)
+		} else {
+			fmt.Printf(" - %s\n", p.Pokemon.Name)
+		}
+	}
+	return nil
+}
+
+func commandItem(conf *config, args ...string) error {
+	if len(args) != 1 {
+		return errors.New("you must provide an item name")
+	}
+
+	item, err := conf.pokeapiClient.FetchItem(args[0])
+	if err != nil {
+		return err
+	}
+	category, err := conf.pokeapiClient.FetchItemCategory(item.Category.Name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (%s, %s pocket)\n", item.Name, category.Name, category.Pocket.Name)
+	fmt.Printf("Cost: %d\n", item.Cost)
+	fmt.Printf("Fling power: %s\n", optionalInt(item.FlingPower))
+	if effect, short, found := item.Effect(conf.language); found {
+		fmt.Printf("Effect: %s\n", short)
+		if effect != short {
+			fmt.Printf("  %s\n", effect)
+		}
+	}
+
+	holders := []string{}
+	for _, held := range item.HeldByPokemon {
+		for _, detail := range held.VersionDetails {
+			holders = append(holders, fmt.Sprintf("%s: %d%% in %s", held.Pokemon.Name, detail.Rarity, detail.Version.Name))
+		}
+	}
+	if len(holders) == 0 {
+		fmt.Println("No wild pokemon hold this item")
+		return nil
+	}
+	fmt.Println("Held by wild pokemon:")
+	for _, holder := range holders {
+		fmt.Println(" -", holder)
+	}
+	return nil
+}
+
+func commandBerries(conf *config, args ...string) error {
+	_, flags := parseFlags(args)
+
+	berries, err := conf.pokeapiClient.FetchAllBerries()
+	if err != nil {
+		return err
+	}
+
+	if name, ok := flags["flavor"]; ok {
+		flavor, err := conf.pokeapiClient.FetchBerryFlavor(name)
+		if err != nil {
+			return err
+		}
+		withFlavor := map[string]bool{}
+		for _, b := range flavor.Berries {
+			withFlavor[b.Berry.Name] = true
+		}
+		berries = slices.DeleteFunc(berries, func(b pokeapi.Berry) bool {
+			return !withFlavor[b.Name]
+		})
+	}
+
+	fmt.Printf("%-10s %-8s %-15s %s\n", "Berry", "Growth", "Natural gift", "Flavors")
+	for _, berry := range berries {
+		flavors := []string{}
+		for _, f := range berry.Flavors {
+			if f.Potency > 0 {
+				flavors = append(flavors, fmt.Sprintf("%s %d", f.Flavor.Name, f.Potency))
+			}
+		}
+		gift := fmt.Sprintf("%s %d", berry.NaturalGiftType.Name, berry.NaturalGiftPower)
+		fmt.Printf("%-10s %-8s %-15s %s\n", berry.Name, fmt.Sprintf("%dh", berry.GrowthTime), gift, strings.Join(flavors, ", "))
+	}
+	return nil
+}
+
+func commandRegion(conf *config, args ...string) error {
+	if len(args) != 1 {
+		return errors.New("you must provide a region name")
+	}
+
+	region, err := conf.pokeapiClient.FetchRegion(args[0])
+	if err != nil {
+		return err
+	}
+	locations, err := conf.pokeapiClient.FetchRegionLocations(region)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (%s)\n", region.Name, region.MainGeneration.Name)
+	for i, loc := range locations {
+		if i == len(locations)-1 {
+			fmt.Println("└─ " + loc.Name)
+			printLocationTree(loc, "   ")
+		} else {
+			fmt.Println("├─ " + loc.Name)
+			printLocationTree(loc, "│  ")
+		}
+	}
+	return nil
+}
+
+func commandLocation(conf *config, args ...string) error {
+	if len(args) != 1 {
+		return errors.New("you must provide a location name")
+	}
+
+	loc, err := conf.pokeapiClient.FetchLocation(args[0])
+	if err != nil {
+		return err
+	}
+
+	if loc.Region != nil {
+		fmt.Printf("%s (%s)\n", loc.Name, loc.Region.Name)
+	} else {
+		fmt.Println(loc.Name)
+	}
+	if len(loc.Areas) == 0 {
+		fmt.Println("No areas to explore")
+		return nil
+	}
+	printLocationTree(loc, "")
+	return nil
+}
+
+func commandWhere(conf *config, args ...string) error {
+	args, flags := parseFlags(args)
+	if len(args) != 1 {
+		return errors.New("you must provide a pokemon name")
+	}
+
+	version := flags["version"]
+
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(args[0])
+	if err != nil {
+		return err
+	}
+	encounters, err := conf.pokeapiClient.FetchLocationAreaEncounters(pokemon.LocationAreaEncounters)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{}
+	for _, area := range encounters {
+		for _, detail := range area.VersionDetails {
+			if version != "" && detail.Version.Name != version {
+				continue
+			}
+			for _, s := range summarizeEncounters(detail.EncounterDetails) {
+				rows = append(rows, []string{
+					area.LocationArea.Name, detail.Version.Name, s.Method,
+					levelRange(s.MinLevel, s.MaxLevel), fmt.Sprintf("%d%%", s.Chance),
+					describeConditions(s.Conditions),
+				})
+			}
+		}
+	}
+	if len(rows) == 0 {
+		if version == "" {
+			fmt.Printf("%s can't be found in the wild\n", pokemon.Name)
+		} else {
+			fmt.Printf("%s can't be found in the wild in %s\n", pokemon.Name, version)
+		}
+		return nil
+	}
+
+	fmt.Printf("%-45s %-9s %-10s %-7s %-6s %s\n", "Area", "Version", "Method", "Levels", "Chance", "Conditions")
+	for _, row := range rows {
+		line := fmt.Sprintf("%-45s %-9s %-10s %-7s %-6s %s", row[0], row[1], row[2], row[3], row[4], row[5])
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	return nil
+}
+
+func commandGoto(conf *config, args ...string) error {
+	if len(args) != 1 {
+		return errors.New("you must provide a location area")
+	}
+	if conf.battle != nil {
+		return errInBattle
+	}
+
+	area, err := conf.pokeapiClient.ListExplore(args[0])
+	if err != nil {
+		return err
+	}
+	conf.location = area.Name
+	conf.wild = nil
+	fmt.Printf("You arrived at %s\n", area.Name)
+	return nil
+}
+
+func commandWalk(conf *config, args ...string) error {
+	return meetWildPokemon(conf, "walk")
+}
+
+func commandSurf(conf *config, args ...string) error {
+	return meetWildPokemon(conf, "surf")
+}
+
+func commandFish(conf *config, args ...string) error {
+	_, flags := parseFlags(args)
+	rod := strings.TrimSuffix(flags["rod"], "-rod")
+	switch rod {
+	case "":
+		rod = "old"
+	case "old", "good", "super":
+	default:
+		return fmt.Errorf("unknown rod %q, expected old, good or super", flags["rod"])
+	}
+	return meetWildPokemon(conf, rod+"-rod")
+}
+
+// meetWildPokemon rolls a wild encounter with method in the current area
+func meetWildPokemon(conf *config, method string) error {
+	if conf.battle != nil {
+		return errInBattle
+	}
+	if conf.location == "" {
+		return errors.New("you aren't anywhere yet, use goto <area> first")
+	}
+
+	area, err := conf.pokeapiClient.ListExplore(conf.location)
+	if err != nil {
+		return err
+	}
+
+	version := encounterVersion(area, method)
+	wild, found := rollEncounter(conf.rng, area, version, method, conf.clock())
+	if !found {
+		fmt.Printf("No wild pokemon can be found with %s here\n", method)
+		return nil
+	}
+
+	if err := markSeen(conf, wild.Name); err != nil {
+		return err
+	}
+	conf.wild = &wild
+	fmt.Printf("A wild %s (Lv. %d) appeared!\n", wild.Name, wild.Level)
+	return nil
+}
+
+func commandParty(conf *config, args ...string) error {
+	if len(conf.storage.Party) == 0 {
+		fmt.Println("Your party is empty")
+		return nil
+	}
+
+	fmt.Printf("Your party (%d/%d):\n", len(conf.storage.Party), owned.PartySize)
+	for i, p := range conf.storage.Party {
+		fmt.Printf(" %d. %s\n", i+1, describeOwned(p))
+	}
+	return nil
+}
+
+func commandBox(conf *config, args ...string) error {
+	if len(args) > 1 {
+		return errors.New("you can only open one box at a time")
+	}
+
+	n := 1
+	if len(args) == 1 {
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("invalid box %q", args[0])
+		}
+	}
+	box, err := conf.storage.Box(n)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Box %d (%d/%d):\n", n, len(box), owned.BoxSize)
+	for _, p := range box {
+		fmt.Println(" -", describeOwned(p))
+	}
+	return nil
+}
+
+func commandDeposit(conf *config, args ...string) error {
+	if conf.battle != nil {
+		return errInBattle
+	}
+	args, flags := parseFlags(args)
+	if len(args) != 1 {
+		return errors.New("you must provide a pokemon name or id")
+	}
+
+	box := 0
+	if b, ok := flags["box"]; ok {
+		var err error
+		if box, err = strconv.Atoi(b); err != nil || box < 1 {
+			return fmt.Errorf("invalid box %q", b)
+		}
+	}
+
+	p := resolveOwned(conf, args[0])
+	if p == nil {
+		return nil
+	}
+	box, err := conf.storage.Deposit(p.ID, box)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s was deposited in box %d\n", p.Name(), box)
+	return nil
+}
+
+func commandWithdraw(conf *config, args ...string) error {
+	if conf.battle != nil {
+		return errInBattle
+	}
+	if len(args) != 1 {
+		return errors.New("you must provide a pokemon name or id")
+	}
+
+	p := resolveOwned(conf, args[0])
+	if p == nil {
+		return nil
+	}
+	if err := conf.storage.Withdraw(p.ID); err != nil {
+		return err
+	}
+	fmt.Printf("%s joined your party\n", p.Name())
+	return nil
+}
+
+func commandSwap(conf *config, args ...string) error {
+	if conf.battle != nil {
+		return errInBattle
+	}
+	if len(args) != 2 {
+		return errors.New("you must provide two pokemon names or ids")
+	}
+
+	a := resolveOwned(conf, args[0])
+	if a == nil {
+		return nil
+	}
+	b := resolveOwned(conf, args[1])
+	if b == nil {
+		return nil
+	}
+	if err := conf.storage.Swap(a.ID, b.ID); err != nil {
+		return err
+	}
+	fmt.Printf("%s and %s swapped places\n", a.Name(), b.Name())
+	return nil
+}
+
+func commandRelease(conf *config, args ...string) error {
+	if conf.battle != nil {
+		return errInBattle
+	}
+	if len(args) != 1 {
+		return errors.New("you must provide a pokemon name or id")
+	}
+
+	p := resolveOwned(conf, args[0])
+	if p == nil {
+		return nil
+	}
+	if _, err := conf.storage.Release(p.ID); err != nil {
+		return err
+	}
+	fmt.Printf("%s was released. Bye-bye, %s!\n", p.Name(), p.Name())
+	return nil
+}
+
+func commandNickname(conf *config, args ...string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("you must provide a pokemon name or id and a nickname")
+	}
+
+	p := resolveOwned(conf, args[0])
+	if p == nil {
+		return nil
+	}
+	if len(args) == 1 {
+		p.Nickname = ""
+		fmt.Printf("%s no longer has a nickname\n", p.Species)
+		return nil
+	}
+
+	if len([]rune(args[1])) > owned.MaxNicknameLength {
+		return fmt.Errorf("nicknames are at most %d characters long", owned.MaxNicknameLength)
+	}
+	// Numbers are taken for ids when looking pokemon up
+	if _, err := strconv.Atoi(strings.TrimPrefix(args[1], "#")); err == nil {
+		return errors.New("nicknames can't be numbers, they would be taken for ids")
 	}
+	p.Nickname = args[1]
+	fmt.Printf("%s is now called %s\n", p.Species, p.Nickname)
 	return nil
 }
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// compareLabelWidth is the width of the first column of compare, wide
+// enough for special-defense
+const compareLabelWidth = 16
+
+// pokemonTypes lists the type names of a Pokemon in slot order
+func pokemonTypes(pokemon pokeapi.PokemonDetails) []string {
+	types := make([]string, 0, len(pokemon.Types))
+	for _, t := range pokemon.Types {
+		types = append(types, t.Type.Name)
+	}
+	return types
+}
+
+// highlightBest renders values, marking the highest with a * unless
+// they are all equal
+func highlightBest(values []int) []string {
+	best := slices.Max(values)
+	tie := slices.Min(values) == best
+	cells := make([]string, 0, len(values))
+	for _, v := range values {
+		cell := strconv.Itoa(v)
+		if v == best && !tie {
+			cell += "*"
+		}
+		cells = append(cells, cell)
+	}
+	return cells
+}
+
+func commandCompare(conf *config, args ...string) error {
+	if len(args) < 2 {
+		return errors.New("usage: compare <pokemon> <pokemon> [...]")
+	}
+
+	fetched, err := pokeapi.FetchConcurrently(args, moveWorkers, func(name string) (pokeapi.PokemonDetails, error) {
+		details, err := conf.pokeapiClient.FetchPokemonDetails(name)
+		if err != nil {
+			return details, suggestNotFound(conf, "pokemon", name, err)
+		}
+		return details, nil
+	})
+	if err != nil {
+		return err
+	}
+	pokemon := make([]pokeapi.PokemonDetails, 0, len(args))
+	for _, name := range args {
+		pokemon = append(pokemon, fetched[name])
+	}
+	chart, err := loadTypeChart(conf)
+	if err != nil {
+		return err
+	}
+
+	widths := make([]int, len(pokemon))
+	for i, p := range pokemon {
+		widths[i] = max(len(p.Name), len(strings.Join(pokemonTypes(p), "/"))) + 2
+	}
+	printRow := func(label string, cells []string) {
+		line := fmt.Sprintf("%-*s", compareLabelWidth, label)
+		for i, cell := range cells {
+			line += fmt.Sprintf("%-*s", widths[i], cell)
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	statRow := func(value func(pokeapi.PokemonDetails) int) []int {
+		values := make([]int, 0, len(pokemon))
+		for _, p := range pokemon {
+			values = append(values, value(p))
+		}
+		return values
+	}
+
+	names := make([]string, 0, len(pokemon))
+	types := make([]string, 0, len(pokemon))
+	for _, p := range pokemon {
+		names = append(names, p.Name)
+		types = append(types, strings.Join(pokemonTypes(p), "/"))
+	}
+	printRow("", names)
+	printRow("types", types)
+	for _, stat := range owned.StatNames {
+		printRow(stat, highlightBest(statRow(func(p pokeapi.PokemonDetails) int {
+			return owned.BaseStats(p).Get(stat)
+		})))
+	}
+	printRow("total", highlightBest(statRow(func(p pokeapi.PokemonDetails) int {
+		return owned.BaseStats(p).Total()
+	})))
+
+	// Height and weight have no better side, they are listed as is
+	for _, row := range []struct {
+		label string
+		value func(pokeapi.PokemonDetails) int
+	}{
+		{"height", func(p pokeapi.PokemonDetails) int { return p.Height }},
+		{"weight", func(p pokeapi.PokemonDetails) int { return p.Weight }},
+	} {
+		cells := []string{}
+		for _, v := range statRow(row.value) {
+			cells = append(cells, strconv.Itoa(v))
+		}
+		printRow(row.label, cells)
+	}
+	fmt.Println("* best base stat")
+
+	fmt.Println("Abilities:")
+	for _, p := range pokemon {
+		abilities := []string{}
+		for _, a := range p.Abilities {
+			if a.IsHidden {
+				abilities = append(abilities, a.Ability.Name+" (hidden)")
+			} else {
+				abilities = append(abilities, a.Ability.Name)
+			}
+		}
+		fmt.Printf(" - %s: %s\n", p.Name, orNone(strings.Join(abilities, ", ")))
+	}
+
+	// Each Pokemon attacks with the most effective of its own types
+	fmt.Println("Matchups:")
+	for i, attacker := range pokemon {
+		for j, defender := range pokemon {
+			if i == j {
+				continue
+			}
+			defending := pokemonTypes(defender)
+			bestType, best := "", -1.0
+			for _, t := range pokemonTypes(attacker) {
+				if m := chart.Multiplier(t, defending...); m > best {
+					bestType, best = t, m
+				}
+			}
+			fmt.Printf(" - %s vs %s: %s %gx\n", attacker.Name, defender.Name, bestType, best)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// encounterSummary aggregates the encounters of one method under the
+// same conditions: the level range across them and their summed chance
+type encounterSummary struct {
+	Method     string
+	Conditions []string
+	MinLevel   int
+	MaxLevel   int
+	Chance     int
+}
+
+// summarizeEncounters groups encounters by method and conditions, in
+// the order they first appear. Chances are capped at 100%.
+func summarizeEncounters(encounters []pokeapi.Encounter) []encounterSummary {
+	summaries := []encounterSummary{}
+	index := map[string]int{}
+	for _, enc := range encounters {
+		conditions := []string{}
+		for _, c := range enc.ConditionValues {
+			conditions = append(conditions, c.Name)
+		}
+		slices.Sort(conditions)
+
+		key := enc.Method.Name + "|" + strings.Join(conditions, ",")
+		i, found := index[key]
+		if !found {
+			index[key] = len(summaries)
+			summaries = append(summaries, encounterSummary{
+				Method:     enc.Method.Name,
+				Conditions: conditions,
+				MinLevel:   enc.MinLevel,
+				MaxLevel:   enc.MaxLevel,
+			})
+			i = len(summaries) - 1
+		}
+		s := &summaries[i]
+		s.MinLevel = min(s.MinLevel, enc.MinLevel)
+		s.MaxLevel = max(s.MaxLevel, enc.MaxLevel)
+		s.Chance = min(s.Chance+enc.Chance, 100)
+	}
+	return summaries
+}
+
+// levelRange renders a level range, e.g. "15-17" or "5"
+func levelRange(minLevel, maxLevel int) string {
+	if minLevel == maxLevel {
+		return fmt.Sprint(minLevel)
+	}
+	return fmt.Sprintf("%d-%d", minLevel, maxLevel)
+}
+
+// describeCondition turns an encounter condition value such as
+// "time-morning" or "radar-on" into plain words
+func describeCondition(value string) string {
+	switch value {
+	case "swarm-yes":
+		return "during a swarm"
+	case "swarm-no":
+		return "outside swarms"
+	case "radar-on":
+		return "with the Poké Radar"
+	case "radar-off":
+		return "without the Poké Radar"
+	case "radio-off":
+		return "with the radio off"
+	case "slot2-none":
+		return "with no game in slot 2"
+	case "time-day":
+		return "during the day"
+	case "time-night":
+		return "at night"
+	}
+
+	group, val, _ := strings.Cut(value, "-")
+	val = strings.ReplaceAll(val, "-", " ")
+	switch group {
+	case "time":
+		return "in the " + val
+	case "season":
+		return "in " + val
+	case "radio":
+		return "with " + val + " sound on the radio"
+	case "slot2":
+		return "with " + val + " in slot 2"
+	case "starter":
+		return "if the starter was " + val
+	}
+	return strings.ReplaceAll(value, "-", " ")
+}
+
+// describeConditions joins the descriptions of condition values
+func describeConditions(values []string) string {
+	described := make([]string, 0, len(values))
+	for _, value := range values {
+		described = append(described, describeCondition(value))
+	}
+	return strings.Join(described, ", ")
+}
+
+// wildPokemon is the Pokemon the trainer is currently facing
+type wildPokemon struct {
+	Name   string
+	Level  int
+	Area   string
+	Method string
+}
+
+// encounterPeriod maps a clock time to the time of day of encounter
+// conditions, which unlike evolutions split morning from day
+func encounterPeriod(t time.Time) string {
+	switch {
+	case t.Hour() >= 4 && t.Hour() < 10:
+		return "morning"
+	case t.Hour() >= 10 && t.Hour() < 20:
+		return "day"
+	}
+	return "night"
+}
+
+// conditionsMet reports whether encounter conditions hold at clock.
+// Only the time of day is tracked, so other conditions only hold in
+// their default state, e.g. with no swarm and the Poké Radar off.
+func conditionsMet(values []pokeapi.NamedAPIResource, clock time.Time) bool {
+	for _, value := range values {
+		group, val, _ := strings.Cut(value.Name, "-")
+		switch {
+		case group == "time":
+			if val != encounterPeriod(clock) {
+				return false
+			}
+		case val == "off" || val == "no" || val == "none":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// encounterVersion picks the version of an area's encounters to use
+// for a method: the latest with encounters
+func encounterVersion(area pokeapi.RespLocationsDetail, method string) string {
+	latest, latestID := "", -1
+	for _, enc := range area.PokemonEncounters {
+		for _, detail := range enc.VersionDetails {
+			for _, d := range detail.EncounterDetails {
+				if d.Method.Name != method {
+					continue
+				}
+				id, err := pokeapi.ResourceID(detail.Version.URL)
+				if err == nil && id > latestID {
+					latest, latestID = detail.Version.Name, id
+				}
+			}
+		}
+	}
+	return latest
+}
+
+// rollEncounter picks a wild Pokemon met with method in an area,
+// weighted by the encounter chances, and a level in its range
+func rollEncounter(rng *rand.Rand, area pokeapi.RespLocationsDetail, version, method string, clock time.Time) (wildPokemon, bool) {
+	type candidate struct {
+		name string
+		enc  pokeapi.Encounter
+	}
+	candidates := []candidate{}
+	total := 0
+	for _, enc := range area.PokemonEncounters {
+		for _, detail := range enc.VersionDetails {
+			if detail.Version.Name != version {
+				continue
+			}
+			for _, d := range detail.EncounterDetails {
+				if d.Method.Name != method || d.Chance <= 0 || !conditionsMet(d.ConditionValues, clock) {
+					continue
+				}
+				candidates = append(candidates, candidate{name: enc.Pokemon.Name, enc: d})
+				total += d.Chance
+			}
+		}
+	}
+	if total == 0 {
+		return wildPokemon{}, false
+	}
+
+	roll := rng.Intn(total)
+	for _, c := range candidates {
+		if roll >= c.enc.Chance {
+			roll -= c.enc.Chance
+			continue
+		}
+		return wildPokemon{
+			Name:   c.name,
+			Level:  c.enc.MinLevel + rng.Intn(max(c.enc.MaxLevel-c.enc.MinLevel, 0)+1),
+			Area:   area.Name,
+			Method: method,
+		}, true
+	}
+	return wildPokemon{}, false
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// evolutionState is what we know about an owned Pokemon when checking
+// whether it meets the conditions of an evolution. A zero Level means
+// the level is unknown.
+type evolutionState struct {
+	Level      int
+	Gender     string
+	Happiness  int
+	UsedItem   string
+	HeldItem   string
+	Traded     bool
+	Clock      time.Time
+	Location   string
+	KnownMoves []string
+}
+
+// isTimeOfDay maps a clock time to the games' day/night cycle. Dusk
+// is the last hour of daylight.
+func isTimeOfDay(t time.Time, period string) bool {
+	switch period {
+	case "day":
+		return t.Hour() >= 4 && t.Hour() < 20
+	case "night":
+		return t.Hour() < 4 || t.Hour() >= 20
+	case "dusk":
+		return t.Hour() == 17
+	}
+	return false
+}
+
+// evolutionMet reports whether state satisfies every condition of the
+// evolution detail. When it doesn't, the first missing condition is
+// returned in plain words.
+func evolutionMet(d pokeapi.EvolutionDetail, state evolutionState) (bool, string) {
+	switch d.Trigger.Name {
+	case "level-up":
+		if state.UsedItem != "" || state.Traded {
+			return false, "needs to level up"
+		}
+	case "use-item":
+		if d.Item == nil || state.UsedItem != d.Item.Name {
+			return false, "needs " + d.Describe()
+		}
+	case "trade":
+		if !state.Traded || state.UsedItem != "" {
+			return false, "needs to be traded"
+		}
+	default:
+		return false, fmt.Sprintf("the %s trigger isn't supported", d.Trigger.Name)
+	}
+
+	if d.MinLevel != nil && state.Level < *d.MinLevel {
+		return false, fmt.Sprintf("needs to reach level %d", *d.MinLevel)
+	}
+	if d.MinHappiness != nil && state.Happiness < *d.MinHappiness {
+		return false, fmt.Sprintf("needs friendship %d (has %d)", *d.MinHappiness, state.Happiness)
+	}
+	if d.TimeOfDay != "" && !isTimeOfDay(state.Clock, d.TimeOfDay) {
+		return false, "needs to be " + d.TimeOfDay + " time"
+	}
+	if d.HeldItem != nil && state.HeldItem != d.HeldItem.Name {
+		return false, "needs to hold " + d.HeldItem.Name
+	}
+	if d.KnownMove != nil && !slices.Contains(state.KnownMoves, d.KnownMove.Name) {
+		return false, "needs to know " + d.KnownMove.Name
+	}
+	if d.Location != nil && state.Location != d.Location.Name {
+		return false, "needs to be at " + d.Location.Name
+	}
+	if d.Gender != nil && state.Gender != pokeapi.GenderName(*d.Gender) {
+		return false, "needs to be " + pokeapi.GenderName(*d.Gender)
+	}
+
+	// Conditions the CLI has no state for can never be met
+	unsupported := d.MinBeauty != nil || d.MinAffection != nil ||
+		d.KnownMoveType != nil || d.PartySpecies != nil || d.PartyType != nil ||
+		d.RelativePhysicalStats != nil || d.TradeSpecies != nil ||
+		d.NeedsOverworldRain || d.TurnUpsideDown
+	if unsupported {
+		return false, "needs " + d.Describe() + ", which can't be tracked yet"
+	}
+
+	return true, ""
+}
+
+// findEvolution returns the first species link can evolve into given
+// state, optionally restricted to the species named into. Otherwise
+// it returns why each candidate can't be reached.
+func findEvolution(link *pokeapi.ChainLink, state evolutionState, into string) (string, []string) {
+	reasons := []string{}
+	for _, next := range link.EvolvesTo {
+		if into != "" && next.Species.Name != into {
+			continue
+		}
+		for _, detail := range next.EvolutionDetails {
+			met, reason := evolutionMet(detail, state)
+			if met {
+				return next.Species.Name, nil
+			}
+			reasons = append(reasons, fmt.Sprintf("%s: %s", next.Species.Name, reason))
+		}
+	}
+	return "", reasons
+}
+
+// printEvolutionTree renders a chain link and its evolutions as a tree
+func printEvolutionTree(link pokeapi.ChainLink, prefix string, last bool, root bool) {
+	label := link.Species.Name
+	if len(link.EvolutionDetails) > 0 {
+		how := []string{}
+		for _, detail := range link.EvolutionDetails {
+			how = append(how, detail.Describe())
+		}
+		label += " (" + strings.Join(how, " or ") + ")"
+	}
+
+	childPrefix := prefix
+	switch {
+	case root:
+		fmt.Println(label)
+	case last:
+		fmt.Println(prefix + "└─ " + label)
+		childPrefix += "   "
+	default:
+		fmt.Println(prefix + "├─ " + label)
+		childPrefix += "│  "
+	}
+
+	for i, next := range link.EvolvesTo {
+		printEvolutionTree(next, childPrefix, i == len(link.EvolvesTo)-1, false)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestEvolutionMet(t *testing.T) {
+	noon := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	levelUp := pokeapi.NamedAPIResource{Name: "level-up"}
+	useItem := pokeapi.NamedAPIResource{Name: "use-item"}
+	trade := pokeapi.NamedAPIResource{Name: "trade"}
+
+	cases := []struct {
+		name   string
+		detail pokeapi.EvolutionDetail
+		state  evolutionState
+		met    bool
+		reason string
+	}{
+		{
+			name:   "level reached",
+			detail: pokeapi.EvolutionDetail{Trigger: levelUp, MinLevel: intPtr(16)},
+			state:  evolutionState{Level: 16},
+			met:    true,
+		},
+		{
+			name:   "level too low",
+			detail: pokeapi.EvolutionDetail{Trigger: levelUp, MinLevel: intPtr(16)},
+			state:  evolutionState{Level: 5},
+			reason: "needs to reach level 16",
+		},
+		{
+			name:   "evolution stone",
+			detail: pokeapi.EvolutionDetail{Trigger: useItem, Item: &pokeapi.NamedAPIResource{Name: "water-stone"}},
+			state:  evolutionState{UsedItem: "water-stone"},
+			met:    true,
+		},
+		{
+			name:   "wrong stone",
+			detail: pokeapi.EvolutionDetail{Trigger: useItem, Item: &pokeapi.NamedAPIResource{Name: "water-stone"}},
+			state:  evolutionState{UsedItem: "fire-stone"},
+			reason: "needs use water-stone",
+		},
+		{
+			name:   "trade",
+			detail: pokeapi.EvolutionDetail{Trigger: trade},
+			state:  evolutionState{Traded: true},
+			met:    true,
+		},
+		{
+			name:   "friendship during the day",
+			detail: pokeapi.EvolutionDetail{Trigger: levelUp, MinHappiness: intPtr(160), TimeOfDay: "day"},
+			state:  evolutionState{Happiness: 200, Clock: noon},
+			met:    true,
+		},
+		{
+			name:   "friendship at the wrong time",
+			detail: pokeapi.EvolutionDetail{Trigger: levelUp, MinHappiness: intPtr(160), TimeOfDay: "day"},
+			state:  evolutionState{Happiness: 200, Clock: midnight},
+			reason: "needs to be day time",
+		},
+		{
+			name:   "low friendship",
+			detail: pokeapi.EvolutionDetail{Trigger: levelUp, MinHappiness: intPtr(220)},
+			state:  evolutionState{Happiness: 50},
+			reason: "needs friendship 220 (has 50)",
+		},
+		{
+			name:   "female only",
+			detail: pokeapi.EvolutionDetail{Trigger: useItem, Item: &pokeapi.NamedAPIResource{Name: "dawn-stone"}, Gender: intPtr(1)},
+			state:  evolutionState{UsedItem: "dawn-stone", Gender: "female"},
+			met:    true,
+		},
+		{
+			name:   "wrong gender",
+			detail: pokeapi.EvolutionDetail{Trigger: useItem, Item: &pokeapi.NamedAPIResource{Name: "dawn-stone"}, Gender: intPtr(1)},
+			state:  evolutionState{UsedItem: "dawn-stone", Gender: "male"},
+			reason: "needs to be female",
+		},
+		{
+			name:   "item given for a trade",
+			detail: pokeapi.EvolutionDetail{Trigger: trade},
+			state:  evolutionState{Traded: true, UsedItem: "water-stone"},
+			reason: "needs to be traded",
+		},
+		{
+			name:   "untracked condition",
+			detail: pokeapi.EvolutionDetail{Trigger: levelUp, MinBeauty: intPtr(171)},
+			state:  evolutionState{Level: 30},
+			reason: "can't be tracked yet",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			met, reason := evolutionMet(c.detail, c.state)
+			if met != c.met {
+				t.Fatalf("expected met=%v, got %v (%s)", c.met, met, reason)
+			}
+			if !strings.Contains(reason, c.reason) {
+				t.Errorf("expected reason %q, got %q", c.reason, reason)
+			}
+		})
+	}
+}
+
+func TestEvolveWithFakeServer(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "evolution eevee")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{"eevee\n", "├─ vaporeon (use water-stone)", "└─ glaceon (level up, at sinnoh-route-217)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected tree to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	runInput(t, cfg, "catch eevee --ball=master-ball --free-catch")
+	if _, err := runInput(t, cfg, "evolve eevee --item=water-stone"); err == nil {
+		t.Error("expected an error without the stone in the bag")
+	}
+	cfg.bag.Add("fire-stone", 1)
+	cfg.bag.Add("water-stone", 1)
+	output, _ = runInput(t, cfg, "evolve eevee --item=fire-stone --into=vaporeon")
+	if !strings.Contains(output, "vaporeon: needs use water-stone") || cfg.bag.Count("fire-stone") != 1 {
+		t.Errorf("expected the missing stone to be reported and the fire-stone kept, got:\n%s", output)
+	}
+
+	output, err = runInput(t, cfg, "evolve eevee --item=water-stone")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "Your eevee evolved into vaporeon!") || cfg.bag.Count("water-stone") != 0 {
+		t.Errorf("expected eevee to evolve using up the stone, got:\n%s", output)
+	}
+	if len(findOwned(cfg, "vaporeon")) != 1 {
+		t.Error("expected vaporeon to replace eevee")
+	}
+	if len(findOwned(cfg, "eevee")) != 0 {
+		t.Error("expected eevee to be gone")
+	}
+
+	output, _ = runInput(t, cfg, "evolve vaporeon")
+	if !strings.Contains(output, "vaporeon does not evolve") {
+		t.Errorf("expected vaporeon to be fully evolved, got:\n%s", output)
+	}
+}
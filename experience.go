@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nurusanwe/pokedexcli/internal/battle"
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// expBarWidth is the number of cells of the experience bar in inspect
+const expBarWidth = 20
+
+// growthRate fetches the growth rate of an owned Pokemon's species
+func growthRate(conf *config, p *owned.Pokemon) (pokeapi.GrowthRate, error) {
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(p.Species)
+	if err != nil {
+		return pokeapi.GrowthRate{}, err
+	}
+	species, err := conf.pokeapiClient.FetchPokemonSpecies(pokemon.Species.Name)
+	if err != nil {
+		return pokeapi.GrowthRate{}, err
+	}
+	return conf.pokeapiClient.FetchGrowthRate(species.GrowthRate.Name)
+}
+
+// levelExperience returns the experience an owned Pokemon has: saves
+// from before experience was tracked start at the bottom of their level
+func levelExperience(p *owned.Pokemon, rate pokeapi.GrowthRate) int {
+	return max(p.Exp, rate.Experience(p.Level))
+}
+
+// expBar renders the progress from one level to the next, e.g.
+// "[#####---------------]"
+func expBar(exp, from, to int) string {
+	filled := expBarWidth
+	if to > from {
+		filled = min(max((exp-from)*expBarWidth/(to-from), 0), expBarWidth)
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", expBarWidth-filled) + "]"
+}
+
+// describeExperience summarizes the experience of an owned Pokemon on
+// one line, e.g. "1800 [##########----------] 397 to level 13"
+func describeExperience(p *owned.Pokemon, rate pokeapi.GrowthRate) string {
+	exp := levelExperience(p, rate)
+	if p.Level >= owned.MaxLevel {
+		return fmt.Sprintf("%d (max level)", exp)
+	}
+	from, to := rate.Experience(p.Level), rate.Experience(p.Level+1)
+	return fmt.Sprintf("%d %s %d to level %d", exp, expBar(exp, from, to), to-exp, p.Level+1)
+}
+
+// awardExperience gives an owned Pokemon the experience for defeating
+// or catching foe
+func awardExperience(conf *config, p *owned.Pokemon, foe owned.Pokemon, trainer bool) error {
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(foe.Species)
+	if err != nil {
+		return err
+	}
+	return gainExperience(conf, p, battle.Experience(pokemon.BaseExperience, foe.Level, trainer))
+}
+
+// gainExperience adds experience to an owned Pokemon, levels it up,
+// teaches it the moves of the levels it reached and checks whether it
+// can evolve
+func gainExperience(conf *config, p *owned.Pokemon, exp int) error {
+	if p.Level >= owned.MaxLevel {
+		return nil
+	}
+	rate, err := growthRate(conf, p)
+	if err != nil {
+		return err
+	}
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(p.Species)
+	if err != nil {
+		return err
+	}
+
+	p.Exp = levelExperience(p, rate) + exp
+	fmt.Printf("%s gained %d Exp. Points!\n", p.Name(), exp)
+	level := min(rate.Level(p.Exp), owned.MaxLevel)
+	if level <= p.Level {
+		return nil
+	}
+
+	base := owned.BaseStats(pokemon)
+	before := p.Stats(base)
+	from := p.Level
+	p.Level = level
+	fmt.Printf("%s grew to level %d!\n", p.Name(), p.Level)
+	after := p.Stats(base)
+	for _, stat := range owned.StatNames {
+		fmt.Printf(" - %s: %d (+%d)\n", stat, after.Get(stat), after.Get(stat)-before.Get(stat))
+	}
+
+	for _, entry := range learnset(pokemon, latestVersionGroup(pokemon), "level-up") {
+		if entry.Level > from && entry.Level <= level {
+			if err := learnMove(conf, p, entry.Move); err != nil {
+				return err
+			}
+		}
+	}
+	return checkLevelUpEvolution(conf, p)
+}
+
+// learnMove teaches an owned Pokemon a move. With four moves known,
+// the player is asked which one to forget.
+func learnMove(conf *config, p *owned.Pokemon, move string) error {
+	for _, known := range p.Moves {
+		if known == move {
+			return nil
+		}
+	}
+	if len(p.Moves) < battle.MaxMoves {
+		p.Moves = append(p.Moves, move)
+		fmt.Printf("%s learned %s!\n", p.Name(), move)
+		return nil
+	}
+
+	fmt.Printf("%s wants to learn %s, but it already knows %d moves:\n", p.Name(), move, len(p.Moves))
+	for i, known := range p.Moves {
+		fmt.Printf(" %d. %s\n", i+1, known)
+	}
+	if conf.prompt == nil {
+		fmt.Printf("Use learn %d %s --forget=<move> to teach it later\n", p.ID, move)
+		return nil
+	}
+
+	answer, err := conf.prompt(fmt.Sprintf("Forget which move for %s? (1-%d, enter to skip) ", move, len(p.Moves)))
+	if err != nil {
+		return err
+	}
+	forget, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || forget < 1 || forget > len(p.Moves) {
+		fmt.Printf("%s did not learn %s\n", p.Name(), move)
+		return nil
+	}
+	replaceMove(p, forget-1, move)
+	return nil
+}
+
+// replaceMove makes an owned Pokemon forget its i-th move for another
+func replaceMove(p *owned.Pokemon, i int, move string) {
+	fmt.Printf("1, 2 and... Poof! %s forgot %s and learned %s!\n", p.Name(), p.Moves[i], move)
+	p.Moves[i] = move
+}
+
+// checkLevelUpEvolution evolves an owned Pokemon that just leveled up
+// when it meets a level-up evolution. The player can cancel it when
+// asked.
+func checkLevelUpEvolution(conf *config, p *owned.Pokemon) error {
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(p.Species)
+	if err != nil {
+		return err
+	}
+	species, err := conf.pokeapiClient.FetchPokemonSpecies(pokemon.Species.Name)
+	if err != nil {
+		return err
+	}
+	chain, err := conf.pokeapiClient.FetchSpeciesEvolutionChain(species.Name)
+	if err != nil {
+		return err
+	}
+	link, found := chain.Chain.Find(species.Name)
+	if !found {
+		return nil
+	}
+
+	state := evolutionState{
+		Level:      p.Level,
+		Happiness:  species.BaseHappiness,
+		Clock:      conf.clock(),
+		Location:   conf.location,
+		KnownMoves: p.Moves,
+	}
+	into, _ := findEvolution(link, state, "")
+	if into == "" {
+		return nil
+	}
+
+	if conf.prompt != nil {
+		answer, err := conf.prompt(fmt.Sprintf("Let %s evolve? (Y/n) ", p.Name()))
+		if err != nil {
+			return err
+		}
+		if a := strings.ToLower(strings.TrimSpace(answer)); a == "n" || a == "no" {
+			fmt.Printf("Huh? %s stopped evolving!\n", p.Name())
+			return nil
+		}
+	}
+	return evolveOwned(conf, p, into)
+}
+
+// evolveOwned turns an owned Pokemon into the species it evolves into
+func evolveOwned(conf *config, p *owned.Pokemon, into string) error {
+	evolved, err := conf.pokeapiClient.FetchPokemonDetails(into)
+	if err != nil {
+		return err
+	}
+
+	name := p.Name()
+	fmt.Printf("What? %s is evolving!\n", name)
+	p.Species = evolved.Name
+	conf.dex.MarkCaught(evolved.Species.Name)
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", name, evolved.Name)
+	return nil
+}
+
+func commandLearn(conf *config, args ...string) error {
+	args, flags := parseFlags(args)
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("you must provide a pokemon and optionally a move")
+	}
+	p := resolveOwned(conf, args[0])
+	if p == nil {
+		return nil
+	}
+	pokemon, err := conf.pokeapiClient.FetchPokemonDetails(p.Species)
+	if err != nil {
+		return err
+	}
+
+	learnable := []string{}
+	for _, entry := range learnset(pokemon, latestVersionGroup(pokemon), "level-up") {
+		if entry.Level <= p.Level && !slices.Contains(p.Moves, entry.Move) && !slices.Contains(learnable, entry.Move) {
+			learnable = append(learnable, entry.Move)
+		}
+	}
+
+	if len(args) == 1 {
+		fmt.Printf("%s knows: %s\n", p.Name(), strings.Join(p.Moves, ", "))
+		if len(learnable) == 0 {
+			fmt.Printf("%s has no other moves to learn at level %d\n", p.Name(), p.Level)
+			return nil
+		}
+		fmt.Printf("%s can learn: %s\n", p.Name(), strings.Join(learnable, ", "))
+		return nil
+	}
+
+	move := args[1]
+	if !slices.Contains(learnable, move) {
+		return fmt.Errorf("%s can't learn %s at level %d", p.Name(), move, p.Level)
+	}
+	forget, ok := flags["forget"]
+	if !ok || len(p.Moves) < battle.MaxMoves {
+		return learnMove(conf, p, move)
+	}
+	i := slices.Index(p.Moves, forget)
+	if i == -1 {
+		return fmt.Errorf("%s doesn't know %s", p.Name(), forget)
+	}
+	replaceMove(p, i, move)
+	return nil
+}
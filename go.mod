@@ -2,8 +2,9 @@ module github.com/nurusanwe/pokedexcli
 
 go 1.23.4
 
+require github.com/peterh/liner v1.2.2
+
 require (
 	github.com/mattn/go-runewidth v0.0.3 // indirect
-	github.com/peterh/liner v1.2.2 // indirect
 	golang.org/x/sys v0.0.0-20211117180635-dee7805ff2e1 // indirect
 )
@@ -0,0 +1,326 @@
+// Package battle simulates a single battle between two Pokémon with
+// the damage formula of the games since Generation V: same-type attack
+// bonus (STAB), type effectiveness, critical hits, accuracy and PP.
+// Status moves and move side effects aren't simulated.
+//
+// Every random roll goes through the *rand.Rand given to New, so a
+// seeded source replays a battle exactly.
+package battle
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+	"github.com/nurusanwe/pokedexcli/internal/typechart"
+)
+
+// MaxMoves is the number of moves a Pokémon can know
+const MaxMoves = 4
+
+// Struggle is used when a Pokémon has no PP left in any move. It has
+// no type and the user takes a quarter of its max HP as recoil.
+var Struggle = Move{Name: "struggle", DamageClass: "physical", Power: 50}
+
+// critChances is the chance of a critical hit at each stage, as
+// 1 in n, since Generation VII
+var critChances = []int{24, 8, 2, 1}
+
+// Move is a move known by a combatant.
+// Power is 0 for moves that deal no regular damage.
+// Accuracy is a percentage, 0 for moves that never miss.
+type Move struct {
+	Name        string
+	Type        string
+	DamageClass string
+	Power       int
+	Accuracy    int
+	Priority    int
+	CritStage   int
+	PP          int
+	MaxPP       int
+}
+
+// NewMove converts a PokéAPI move with its full PP
+func NewMove(m pokeapi.Move) Move {
+	move := Move{
+		Name:        m.Name,
+		Type:        m.Type.Name,
+		DamageClass: m.DamageClass.Name,
+		Priority:    m.Priority,
+	}
+	if m.Power != nil {
+		move.Power = *m.Power
+	}
+	if m.Accuracy != nil {
+		move.Accuracy = *m.Accuracy
+	}
+	if m.PP != nil {
+		move.PP, move.MaxPP = *m.PP, *m.PP
+	}
+	if m.Meta != nil {
+		move.CritStage = m.Meta.CritRate
+	}
+	return move
+}
+
+// Combatant is a Pokémon taking part in a battle
+type Combatant struct {
+	Name  string
+	Level int
+	Types []string
+	Stats owned.Stats
+	HP    int
+	Moves []*Move
+}
+
+// NewCombatant returns a combatant at full HP knowing up to MaxMoves
+// of the given moves
+func NewCombatant(name string, level int, types []string, stats owned.Stats, moves []Move) *Combatant {
+	c := &Combatant{Name: name, Level: level, Types: types, Stats: stats, HP: stats.HP}
+	for i := range moves[:min(len(moves), MaxMoves)] {
+		move := moves[i]
+		c.Moves = append(c.Moves, &move)
+	}
+	return c
+}
+
+// Fainted reports whether the combatant has no HP left
+func (c *Combatant) Fainted() bool {
+	return c.HP <= 0
+}
+
+// UsableMoves returns the indexes of the moves with PP left
+func (c *Combatant) UsableMoves() []int {
+	usable := []int{}
+	for i, move := range c.Moves {
+		if move.PP > 0 {
+			usable = append(usable, i)
+		}
+	}
+	return usable
+}
+
+// Event is what happened when a combatant used a move
+type Event struct {
+	Attacker      string
+	Defender      string
+	Move          string
+	Missed        bool
+	Damage        int
+	Critical      bool
+	Effectiveness float64
+	Recoil        int
+	Fainted       bool
+	AttackerDown  bool
+}
+
+// Battle is a battle between two combatants
+type Battle struct {
+	rng   *rand.Rand
+	chart *typechart.Chart
+	A, B  *Combatant
+	Turns int
+}
+
+// New starts a battle. chart provides type effectiveness.
+func New(rng *rand.Rand, chart *typechart.Chart, a, b *Combatant) *Battle {
+	return &Battle{rng: rng, chart: chart, A: a, B: b}
+}
+
+// Over reports whether either side has fainted
+func (b *Battle) Over() bool {
+	return b.A.Fainted() || b.B.Fainted()
+}
+
+// Winner returns the combatant left standing, or nil while the battle
+// isn't over
+func (b *Battle) Winner() *Combatant {
+	switch {
+	case b.B.Fainted():
+		return b.A
+	case b.A.Fainted():
+		return b.B
+	}
+	return nil
+}
+
+// RandomMove picks one of the usable moves of c, or -1 for Struggle
+func (b *Battle) RandomMove(c *Combatant) int {
+	usable := c.UsableMoves()
+	if len(usable) == 0 {
+		return -1
+	}
+	return usable[b.rng.Intn(len(usable))]
+}
+
+// Turn plays a turn where A uses moveA and B uses moveB, indexes into
+// their moves or -1 for Struggle. Struggle is only allowed once every
+// move is out of PP. The faster combatant goes first unless the other
+// move has a higher priority.
+func (b *Battle) Turn(moveA, moveB int) ([]Event, error) {
+	if b.Over() {
+		return nil, errors.New("the battle is over")
+	}
+	a, err := b.pick(b.A, moveA)
+	if err != nil {
+		return nil, err
+	}
+	bMove, err := b.pick(b.B, moveB)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Turns++
+	first, second := b.order(a, bMove)
+	events := []Event{}
+	for _, action := range []action{first, second} {
+		if action.user.Fainted() || action.target.Fainted() {
+			break
+		}
+		events = append(events, b.use(action))
+	}
+	return events, nil
+}
+
+// FoeTurn plays a turn where only B moves, as when A throws a ball or
+// uses an item instead of attacking
+func (b *Battle) FoeTurn(moveB int) ([]Event, error) {
+	if b.Over() {
+		return nil, errors.New("the battle is over")
+	}
+	action, err := b.pick(b.B, moveB)
+	if err != nil {
+		return nil, err
+	}
+	b.Turns++
+	return []Event{b.use(action)}, nil
+}
+
+// action is a combatant using a move on its opponent
+type action struct {
+	user   *Combatant
+	target *Combatant
+	move   *Move
+}
+
+// pick validates a move choice
+func (b *Battle) pick(c *Combatant, index int) (action, error) {
+	target := b.B
+	if c == b.B {
+		target = b.A
+	}
+
+	if index == -1 {
+		if len(c.UsableMoves()) > 0 {
+			return action{}, fmt.Errorf("%s can only struggle once out of PP", c.Name)
+		}
+		struggle := Struggle
+		return action{user: c, target: target, move: &struggle}, nil
+	}
+	if index < 0 || index >= len(c.Moves) {
+		return action{}, fmt.Errorf("%s doesn't know move %d", c.Name, index+1)
+	}
+	if c.Moves[index].PP <= 0 {
+		return action{}, fmt.Errorf("%s has no PP left", c.Moves[index].Name)
+	}
+	return action{user: c, target: target, move: c.Moves[index]}, nil
+}
+
+// order sorts two actions by priority, then speed, then a coin flip
+func (b *Battle) order(x, y action) (action, action) {
+	switch {
+	case x.move.Priority != y.move.Priority:
+		if x.move.Priority > y.move.Priority {
+			return x, y
+		}
+		return y, x
+	case x.user.Stats.Speed != y.user.Stats.Speed:
+		if x.user.Stats.Speed > y.user.Stats.Speed {
+			return x, y
+		}
+		return y, x
+	case b.rng.Intn(2) == 0:
+		return x, y
+	}
+	return y, x
+}
+
+// use resolves one move
+func (b *Battle) use(a action) Event {
+	if a.move.MaxPP > 0 {
+		a.move.PP--
+	}
+	event := Event{Attacker: a.user.Name, Defender: a.target.Name, Move: a.move.Name, Effectiveness: 1}
+
+	if a.move.Accuracy > 0 && b.rng.Intn(100) >= a.move.Accuracy {
+		event.Missed = true
+		return event
+	}
+	if a.move.Power == 0 || a.move.DamageClass == "status" {
+		return event
+	}
+
+	event.Damage, event.Critical, event.Effectiveness = b.Damage(a.user, a.target, a.move)
+	a.target.HP = max(a.target.HP-event.Damage, 0)
+	event.Fainted = a.target.Fainted()
+
+	if a.move.Name == Struggle.Name {
+		event.Recoil = max(a.user.Stats.HP/4, 1)
+		a.user.HP = max(a.user.HP-event.Recoil, 0)
+		event.AttackerDown = a.user.Fainted()
+	}
+	return event
+}
+
+// Damage rolls the damage of a move:
+//
+//	((2*Level/5 + 2) * Power * A/D) / 50 + 2
+//
+// then multiplied by 1.5 on a critical hit, a random 85-100%, 1.5 for
+// STAB and the type effectiveness. A and D are the attack and defense
+// stats matching the move's damage class.
+func (b *Battle) Damage(attacker, defender *Combatant, move *Move) (damage int, critical bool, effectiveness float64) {
+	attack, defense := attacker.Stats.Attack, defender.Stats.Defense
+	if move.DamageClass == "special" {
+		attack, defense = attacker.Stats.SpecialAttack, defender.Stats.SpecialDefense
+	}
+
+	effectiveness = 1
+	if move.Type != "" {
+		effectiveness = b.chart.Multiplier(move.Type, defender.Types...)
+	}
+	if effectiveness == 0 {
+		return 0, false, 0
+	}
+
+	damage = (2*attacker.Level/5+2)*move.Power*attack/max(defense, 1)/50 + 2
+
+	critical = b.rng.Intn(critChances[min(max(move.CritStage, 0), len(critChances)-1)]) == 0
+	if critical {
+		damage = damage * 3 / 2
+	}
+	damage = damage * (85 + b.rng.Intn(16)) / 100
+	for _, t := range attacker.Types {
+		if t == move.Type {
+			damage = damage * 3 / 2
+			break
+		}
+	}
+	damage = int(float64(damage) * effectiveness)
+	return max(damage, 1), critical, effectiveness
+}
+
+// Experience returns the experience earned for defeating or catching
+// a Pokémon, with the formula of Generations I to IV: its base
+// experience times its level over 7, and half again more when it
+// belonged to a trainer
+func Experience(baseExperience, level int, trainer bool) int {
+	exp := baseExperience * level / 7
+	if trainer {
+		exp = exp * 3 / 2
+	}
+	return max(exp, 1)
+}
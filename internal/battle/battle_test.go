@@ -0,0 +1,261 @@
+package battle
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+	"github.com/nurusanwe/pokedexcli/internal/typechart"
+)
+
+func testChart() *typechart.Chart {
+	newType := func(name string, double, none []string) pokeapi.Type {
+		t := pokeapi.Type{Name: name}
+		for _, n := range double {
+			t.DamageRelations.DoubleDamageTo = append(t.DamageRelations.DoubleDamageTo, pokeapi.NamedAPIResource{Name: n})
+		}
+		for _, n := range none {
+			t.DamageRelations.NoDamageTo = append(t.DamageRelations.NoDamageTo, pokeapi.NamedAPIResource{Name: n})
+		}
+		return t
+	}
+	return typechart.New([]pokeapi.Type{
+		newType("normal", nil, []string{"ghost"}),
+		newType("water", []string{"fire"}, nil),
+		newType("electric", []string{"water"}, []string{"ground"}),
+	})
+}
+
+// combatant returns a level 50 combatant with 100 in every stat
+func combatant(name string, types ...string) *Combatant {
+	stats := owned.Stats{HP: 100, Attack: 100, Defense: 100, SpecialAttack: 100, SpecialDefense: 100, Speed: 100}
+	return NewCombatant(name, 50, types, stats, []Move{
+		{Name: "tackle", Type: "normal", DamageClass: "physical", Power: 90, PP: 35, MaxPP: 35},
+		{Name: "thunderbolt", Type: "electric", DamageClass: "special", Power: 90, Accuracy: 100, PP: 15, MaxPP: 15},
+		{Name: "growl", Type: "normal", DamageClass: "status", Accuracy: 100, PP: 40, MaxPP: 40},
+	})
+}
+
+func TestNewMove(t *testing.T) {
+	power, accuracy, pp := 90, 100, 15
+	m := pokeapi.Move{Name: "thunderbolt", Power: &power, Accuracy: &accuracy, PP: &pp}
+	m.Type.Name = "electric"
+	m.DamageClass.Name = "special"
+	got := NewMove(m)
+	want := Move{Name: "thunderbolt", Type: "electric", DamageClass: "special", Power: 90, Accuracy: 100, PP: 15, MaxPP: 15}
+	if got != want {
+		t.Errorf("NewMove() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDamage(t *testing.T) {
+	chart := testChart()
+	b := New(rand.New(rand.NewSource(1)), chart, combatant("a", "fire"), combatant("b", "water"))
+
+	// (2*50/5+2)*90*100/100/50+2 = 41 before the random factor, 61 on
+	// a critical hit
+	tackle := b.A.Moves[0]
+	lowest, highest := 100, 0
+	for range 1000 {
+		damage, critical, effectiveness := b.Damage(b.A, b.B, tackle)
+		if effectiveness != 1 {
+			t.Fatalf("effectiveness = %v, want 1", effectiveness)
+		}
+		if !critical && (damage < 34 || damage > 41) {
+			t.Fatalf("damage = %d, want 34-41", damage)
+		}
+		lowest, highest = min(lowest, damage), max(highest, damage)
+	}
+	if lowest != 34 || highest != 61 {
+		t.Errorf("damage range = %d-%d, want 34-61", lowest, highest)
+	}
+
+	// Super effective, and with STAB
+	bolt := b.A.Moves[1]
+	damage, critical, effectiveness := b.Damage(b.A, b.B, bolt)
+	if effectiveness != 2 || critical || damage < 68 || damage > 82 {
+		t.Errorf("super effective damage = %d (critical %v, x%v), want 68-82", damage, critical, effectiveness)
+	}
+	b.A.Types = []string{"electric"}
+	damage, critical, _ = b.Damage(b.A, b.B, bolt)
+	if critical || damage < 102 || damage > 123 {
+		t.Errorf("STAB damage = %d (critical %v), want 102-123", damage, critical)
+	}
+
+	// Immunity
+	b.B.Types = []string{"ghost"}
+	damage, _, effectiveness = b.Damage(b.A, b.B, tackle)
+	if damage != 0 || effectiveness != 0 {
+		t.Errorf("immune damage = %d (x%v), want 0", damage, effectiveness)
+	}
+
+	// Stage 3 always lands a critical hit
+	b.B.Types = nil
+	for range 100 {
+		if _, critical, _ := b.Damage(b.A, b.B, &Move{Name: "storm-throw", Type: "normal", Power: 60, CritStage: 3}); !critical {
+			t.Fatal("stage 3 move didn't land a critical hit")
+		}
+	}
+}
+
+func TestTurn(t *testing.T) {
+	a, b := combatant("fast"), combatant("slow")
+	a.Stats.Speed = 120
+	battle := New(rand.New(rand.NewSource(1)), testChart(), a, b)
+
+	events, err := battle.Turn(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Attacker != "fast" || events[1].Attacker != "slow" {
+		t.Fatalf("turn order = %+v, want fast then slow", events)
+	}
+	if events[0].Damage != 0 || a.HP != 100 || b.HP != 100 {
+		t.Errorf("status move dealt damage: %+v", events[0])
+	}
+	if a.Moves[2].PP != 39 || b.Moves[2].PP != 39 {
+		t.Errorf("PP = %d/%d, want 39", a.Moves[2].PP, b.Moves[2].PP)
+	}
+
+	// Priority goes before speed
+	b.Moves[0].Priority = 1
+	events, err = battle.Turn(2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[0].Attacker != "slow" || a.HP != 100-events[0].Damage {
+		t.Errorf("priority move went second: %+v", events)
+	}
+
+	// A fainted defender doesn't move
+	b.Moves[0].Priority = 0
+	b.HP = 1
+	events, err = battle.Turn(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || !events[0].Fainted || !battle.Over() || battle.Winner() != a {
+		t.Errorf("events = %+v, want slow to faint", events)
+	}
+	if _, err := battle.Turn(0, 0); err == nil {
+		t.Error("Turn() after the battle is over didn't fail")
+	}
+}
+
+func TestFoeTurn(t *testing.T) {
+	a, b := combatant("player"), combatant("foe")
+	battle := New(rand.New(rand.NewSource(1)), testChart(), a, b)
+
+	events, err := battle.FoeTurn(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Attacker != "foe" || a.HP != 100-events[0].Damage || b.HP != 100 {
+		t.Errorf("events = %+v, want only foe to attack", events)
+	}
+	if b.Moves[0].PP != b.Moves[0].MaxPP-1 || battle.Turns != 1 {
+		t.Errorf("PP = %d, turns = %d, want one move used", b.Moves[0].PP, battle.Turns)
+	}
+
+	a.HP = 0
+	if _, err := battle.FoeTurn(0); err == nil {
+		t.Error("FoeTurn() after the battle is over didn't fail")
+	}
+}
+
+func TestTurnPP(t *testing.T) {
+	a, b := combatant("a"), combatant("b")
+	battle := New(rand.New(rand.NewSource(1)), testChart(), a, b)
+
+	if _, err := battle.Turn(-1, 2); err == nil {
+		t.Error("Struggle with PP left didn't fail")
+	}
+	if _, err := battle.Turn(5, 2); err == nil {
+		t.Error("unknown move didn't fail")
+	}
+	a.Moves[2].PP = 0
+	if _, err := battle.Turn(2, 2); err == nil {
+		t.Error("move without PP didn't fail")
+	}
+
+	for _, move := range a.Moves {
+		move.PP = 0
+	}
+	if got := battle.RandomMove(a); got != -1 {
+		t.Errorf("RandomMove() = %d, want -1", got)
+	}
+	events, err := battle.Turn(-1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, event := range events {
+		if event.Move == "struggle" && (event.Damage == 0 || event.Recoil != 25 || a.HP != 75) {
+			t.Errorf("struggle = %+v with %d HP left, want 25 recoil", event, a.HP)
+		}
+	}
+}
+
+func TestAccuracy(t *testing.T) {
+	a, b := combatant("a"), combatant("b")
+	a.Moves[1].Accuracy = 50
+	a.Moves[1].PP = 1000
+	b.Moves[2].PP = 1000
+	battle := New(rand.New(rand.NewSource(1)), testChart(), a, b)
+
+	missed := 0
+	for range 200 {
+		b.HP = b.Stats.HP
+		events, err := battle.Turn(1, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, event := range events {
+			if event.Attacker == "a" && event.Missed {
+				missed++
+			}
+		}
+	}
+	if missed < 70 || missed > 130 {
+		t.Errorf("missed %d of 200 at 50%% accuracy", missed)
+	}
+}
+
+func TestSeededBattle(t *testing.T) {
+	play := func() []Event {
+		a, b := combatant("a", "electric"), combatant("b", "water")
+		battle := New(rand.New(rand.NewSource(42)), testChart(), a, b)
+		all := []Event{}
+		for !battle.Over() {
+			events, err := battle.Turn(battle.RandomMove(a), battle.RandomMove(b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			all = append(all, events...)
+		}
+		return all
+	}
+	first, second := play(), play()
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("seeded battles differ:\n%+v\n%+v", first, second)
+	}
+}
+
+func TestExperience(t *testing.T) {
+	cases := []struct {
+		base, level int
+		trainer     bool
+		want        int
+	}{
+		{base: 50, level: 3, want: 21},
+		{base: 50, level: 3, trainer: true, want: 31},
+		{base: 608, level: 100, want: 8685},
+		{base: 0, level: 1, want: 1},
+	}
+	for _, c := range cases {
+		if got := Experience(c.base, c.level, c.trainer); got != c.want {
+			t.Errorf("Experience(%d, %d, %v) = %d, want %d", c.base, c.level, c.trainer, got, c.want)
+		}
+	}
+}
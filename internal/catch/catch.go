@@ -0,0 +1,146 @@
+// Package catch implements the capture formula of the Generation III
+// and IV games: a catch value is derived from the species capture rate,
+// the target's remaining HP, the ball and any status condition, then
+// the ball has to pass four shake checks.
+package catch
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Shakes is the number of shake checks needed for a capture
+const Shakes = 4
+
+// maxCatchValue is the catch value at which a capture is guaranteed
+const maxCatchValue = 255
+
+// Balls maps ball item names to their catch modifier
+var Balls = map[string]float64{
+	"poke-ball":    1,
+	"premier-ball": 1,
+	"luxury-ball":  1,
+	"heal-ball":    1,
+	"great-ball":   1.5,
+	"safari-ball":  1.5,
+	"ultra-ball":   2,
+	"master-ball":  255,
+}
+
+// Statuses maps status conditions to their catch bonus
+var Statuses = map[string]float64{
+	"none":      1,
+	"sleep":     2,
+	"freeze":    2,
+	"paralysis": 1.5,
+	"poison":    1.5,
+	"burn":      1.5,
+}
+
+// Attempt describes a single ball throw.
+// CaptureRate is the species capture_rate (3-255).
+// MaxHP and CurrentHP are the target's hit points; only their ratio matters.
+// Ball and Status are keys of Balls and Statuses, empty means the default.
+type Attempt struct {
+	CaptureRate int
+	MaxHP       int
+	CurrentHP   int
+	Ball        string
+	Status      string
+}
+
+// Result is the outcome of a throw. Shakes counts the shake checks
+// the ball passed before the Pokémon broke free (Shakes when caught).
+type Result struct {
+	Caught bool
+	Shakes int
+}
+
+// Validate reports unknown balls or statuses
+func (a Attempt) Validate() error {
+	if _, ok := Balls[a.ball()]; !ok {
+		return fmt.Errorf("unknown ball %q, expected one of %v", a.Ball, sortedKeys(Balls))
+	}
+	if _, ok := Statuses[a.status()]; !ok {
+		return fmt.Errorf("unknown status %q, expected one of %v", a.Status, sortedKeys(Statuses))
+	}
+	return nil
+}
+
+// CatchValue computes the modified catch rate "a" of the games:
+// ((3*MaxHP - 2*CurrentHP) * CaptureRate * Ball) / (3*MaxHP) * Status
+func (a Attempt) CatchValue() float64 {
+	maxHP := max(a.MaxHP, 1)
+	currentHP := min(max(a.CurrentHP, 1), maxHP)
+
+	value := float64(3*maxHP-2*currentHP) * float64(a.CaptureRate) * Balls[a.ball()]
+	value = math.Floor(value / float64(3*maxHP))
+	return value * Statuses[a.status()]
+}
+
+// ShakeThreshold computes "b": each shake check succeeds when a
+// random number in [0, 65536) is below it.
+func (a Attempt) ShakeThreshold() int {
+	value := a.CatchValue()
+	if value <= 0 {
+		return 0
+	}
+	if value >= maxCatchValue {
+		return 65536
+	}
+	return int(1048560 / math.Sqrt(math.Sqrt(16711680/value)))
+}
+
+// Probability returns the chance of the attempt resulting in a capture
+func (a Attempt) Probability() float64 {
+	if a.CatchValue() >= maxCatchValue {
+		return 1
+	}
+	return math.Pow(float64(a.ShakeThreshold())/65536, Shakes)
+}
+
+// Throw rolls the shake checks for the attempt using rng
+func Throw(rng *rand.Rand, a Attempt) Result {
+	if a.CatchValue() >= maxCatchValue {
+		return Result{Caught: true, Shakes: Shakes}
+	}
+
+	threshold := a.ShakeThreshold()
+	result := Result{}
+	for result.Shakes < Shakes {
+		if rng.Intn(65536) >= threshold {
+			return result
+		}
+		result.Shakes++
+	}
+	result.Caught = true
+	return result
+}
+
+// ball returns the ball name, defaulting to a Poké Ball
+func (a Attempt) ball() string {
+	if a.Ball == "" {
+		return "poke-ball"
+	}
+	return a.Ball
+}
+
+// status returns the status name, defaulting to no status
+func (a Attempt) status() string {
+	if a.Status == "" {
+		return "none"
+	}
+	return a.Status
+}
+
+// sortedKeys lists the keys of m alphabetically for error messages
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
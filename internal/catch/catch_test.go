@@ -0,0 +1,108 @@
+package catch
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestProbability(t *testing.T) {
+	cases := []struct {
+		name     string
+		attempt  Attempt
+		expected float64
+	}{
+		{
+			name:     "common pokemon at full hp",
+			attempt:  Attempt{CaptureRate: 255, MaxHP: 100, CurrentHP: 100},
+			expected: 0.3336,
+		},
+		{
+			name:     "starter at full hp",
+			attempt:  Attempt{CaptureRate: 45, MaxHP: 100, CurrentHP: 100},
+			expected: 0.0588,
+		},
+		{
+			name:     "starter at 1 hp, asleep, in an ultra ball",
+			attempt:  Attempt{CaptureRate: 45, MaxHP: 100, CurrentHP: 1, Ball: "ultra-ball", Status: "sleep"},
+			expected: 0.6980,
+		},
+		{
+			name:     "guaranteed catch value",
+			attempt:  Attempt{CaptureRate: 255, MaxHP: 100, CurrentHP: 1, Ball: "great-ball"},
+			expected: 1,
+		},
+		{
+			name:     "legendary in a master ball",
+			attempt:  Attempt{CaptureRate: 3, MaxHP: 100, CurrentHP: 100, Ball: "master-ball"},
+			expected: 1,
+		},
+		{
+			name:     "no capture rate",
+			attempt:  Attempt{CaptureRate: 0, MaxHP: 100, CurrentHP: 100},
+			expected: 0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := c.attempt.Probability()
+			if math.Abs(got-c.expected) > 0.0005 {
+				t.Errorf("expected probability %.4f, got %.4f", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestThrowMatchesProbability(t *testing.T) {
+	const throws = 100000
+	attempts := []Attempt{
+		{CaptureRate: 45, MaxHP: 100, CurrentHP: 100},
+		{CaptureRate: 190, MaxHP: 35, CurrentHP: 10, Status: "paralysis"},
+		{CaptureRate: 3, MaxHP: 100, CurrentHP: 50, Ball: "ultra-ball"},
+	}
+
+	rng := rand.New(rand.NewSource(42))
+	for _, a := range attempts {
+		caught := 0
+		for i := 0; i < throws; i++ {
+			result := Throw(rng, a)
+			if result.Caught {
+				caught++
+				if result.Shakes != Shakes {
+					t.Fatalf("expected %d shakes on a capture, got %d", Shakes, result.Shakes)
+				}
+			} else if result.Shakes >= Shakes {
+				t.Fatalf("expected fewer than %d shakes on an escape, got %d", Shakes, result.Shakes)
+			}
+		}
+
+		got := float64(caught) / throws
+		if math.Abs(got-a.Probability()) > 0.01 {
+			t.Errorf("%+v: expected a catch rate near %.3f, got %.3f", a, a.Probability(), got)
+		}
+	}
+}
+
+func TestLowerHPHelps(t *testing.T) {
+	full := Attempt{CaptureRate: 45, MaxHP: 100, CurrentHP: 100}
+	weak := Attempt{CaptureRate: 45, MaxHP: 100, CurrentHP: 10}
+	if weak.Probability() <= full.Probability() {
+		t.Errorf("expected a weakened pokemon to be easier to catch")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := (Attempt{Ball: "ultra-ball", Status: "burn"}).Validate(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := (Attempt{}).Validate(); err != nil {
+		t.Errorf("expected defaults to be valid, got %v", err)
+	}
+	if err := (Attempt{Ball: "beach-ball"}).Validate(); err == nil {
+		t.Error("expected an error for an unknown ball")
+	}
+	if err := (Attempt{Status: "confused"}).Validate(); err == nil {
+		t.Error("expected an error for an unknown status")
+	}
+}
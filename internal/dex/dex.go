@@ -0,0 +1,98 @@
+// Package dex tracks the Pokédex of a trainer: the species they have
+// seen and the ones they have caught.
+package dex
+
+// Status is how far a species is registered in the Pokédex
+type Status int
+
+// Statuses from least to most registered
+const (
+	Unseen Status = iota
+	Seen
+	Caught
+)
+
+func (s Status) String() string {
+	switch s {
+	case Seen:
+		return "seen"
+	case Caught:
+		return "caught"
+	}
+	return "unseen"
+}
+
+// Dex holds the species seen and caught, by name. Caught species are
+// always seen as well.
+type Dex struct {
+	Seen   map[string]bool `json:"seen"`
+	Caught map[string]bool `json:"caught"`
+}
+
+// New returns an empty Pokédex
+func New() *Dex {
+	return &Dex{Seen: map[string]bool{}, Caught: map[string]bool{}}
+}
+
+// MarkSeen registers species as seen
+func (d *Dex) MarkSeen(species ...string) {
+	if d.Seen == nil {
+		d.Seen = map[string]bool{}
+	}
+	for _, s := range species {
+		d.Seen[s] = true
+	}
+}
+
+// MarkCaught registers species as caught, and so seen
+func (d *Dex) MarkCaught(species ...string) {
+	if d.Caught == nil {
+		d.Caught = map[string]bool{}
+	}
+	d.MarkSeen(species...)
+	for _, s := range species {
+		d.Caught[s] = true
+	}
+}
+
+// Status returns how far a species is registered
+func (d *Dex) Status(species string) Status {
+	switch {
+	case d.Caught[species]:
+		return Caught
+	case d.Seen[species]:
+		return Seen
+	}
+	return Unseen
+}
+
+// Completion counts how many of a list of species were seen and caught
+type Completion struct {
+	Total  int
+	Seen   int
+	Caught int
+}
+
+// Complete counts the species of a list, such as a regional dex or a
+// generation, that are registered
+func (d *Dex) Complete(species []string) Completion {
+	c := Completion{Total: len(species)}
+	for _, s := range species {
+		switch d.Status(s) {
+		case Caught:
+			c.Caught++
+			c.Seen++
+		case Seen:
+			c.Seen++
+		}
+	}
+	return c
+}
+
+// Percent returns the share of the species caught, rounded down
+func (c Completion) Percent() int {
+	if c.Total == 0 {
+		return 0
+	}
+	return c.Caught * 100 / c.Total
+}
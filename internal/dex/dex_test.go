@@ -0,0 +1,67 @@
+package dex
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	d := New()
+	d.MarkSeen("bidoof", "starly")
+	d.MarkCaught("starly", "pikachu")
+
+	cases := map[string]Status{
+		"bidoof":  Seen,
+		"starly":  Caught,
+		"pikachu": Caught,
+		"shinx":   Unseen,
+	}
+	for species, want := range cases {
+		if got := d.Status(species); got != want {
+			t.Errorf("Status(%q) = %v, want %v", species, got, want)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	d := New()
+	d.MarkSeen("bidoof", "starly")
+	d.MarkCaught("starly")
+
+	got := d.Complete([]string{"turtwig", "starly", "bidoof"})
+	want := Completion{Total: 3, Seen: 2, Caught: 1}
+	if got != want {
+		t.Errorf("Complete() = %+v, want %+v", got, want)
+	}
+	if got.Percent() != 33 {
+		t.Errorf("Percent() = %d, want 33", got.Percent())
+	}
+	if (Completion{}).Percent() != 0 {
+		t.Error("Percent() of nothing isn't 0")
+	}
+}
+
+func TestZeroDex(t *testing.T) {
+	// Saves decode into a zero Dex when the maps are missing
+	d := &Dex{}
+	if err := json.Unmarshal([]byte(`{}`), d); err != nil {
+		t.Fatal(err)
+	}
+	d.MarkCaught("pikachu")
+	if d.Status("pikachu") != Caught {
+		t.Errorf("Status() = %v, want caught", d.Status("pikachu"))
+	}
+
+	dat, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := New()
+	if err := json.Unmarshal(dat, restored); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(restored, d) {
+		t.Errorf("round trip = %+v, want %+v", restored, d)
+	}
+}
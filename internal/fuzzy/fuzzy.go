@@ -0,0 +1,150 @@
+// Package fuzzy ranks names such as PokéAPI resource names against a
+// search text, tolerating a few typos
+package fuzzy
+
+import (
+	"slices"
+	"strings"
+)
+
+// Kind is how a name matched, from the best to the loosest
+type Kind int
+
+const (
+	Exact Kind = iota
+	Prefix
+	Contains
+	Typo
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Exact:
+		return "exact"
+	case Prefix:
+		return "prefix"
+	case Contains:
+		return "contains"
+	default:
+		return "typo"
+	}
+}
+
+// Match is a name matching a search text
+type Match struct {
+	Name string
+	Kind Kind
+	// Distance is the number of typos between the text and the name,
+	// or the closest of its hyphenated words
+	Distance int
+}
+
+// Normalize lowercases text and joins its words with hyphens like the
+// PokéAPI names, so "Great Ball" finds great-ball
+func Normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(text, "-", " "))), "-")
+}
+
+// MaxTypos is how many typos a text of n letters tolerates: none for
+// one or two letters, one up to six and two beyond
+func MaxTypos(n int) int {
+	return min((n+1)/4, 2)
+}
+
+// Rank returns the names matching text, best first: exact matches,
+// then prefixes, names containing the text and names within a few
+// typos of it. Ties go to the closest and shortest names.
+func Rank(text string, names []string) []Match {
+	text = Normalize(text)
+	if text == "" {
+		return nil
+	}
+	maxTypos := MaxTypos(len(text))
+
+	matches := []Match{}
+	for _, name := range names {
+		m := Match{Name: name}
+		switch {
+		case name == text:
+			m.Kind = Exact
+		case strings.HasPrefix(name, text):
+			m.Kind = Prefix
+		case strings.Contains(name, text):
+			m.Kind = Contains
+		default:
+			m.Kind = Typo
+			m.Distance = closest(text, name)
+			if m.Distance > maxTypos {
+				continue
+			}
+		}
+		matches = append(matches, m)
+	}
+
+	slices.SortFunc(matches, func(a, b Match) int {
+		if a.Kind != b.Kind {
+			return int(a.Kind) - int(b.Kind)
+		}
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) - len(b.Name)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return matches
+}
+
+// Suggest returns the closest name to a text that matches none of the
+// names exactly, for "did you mean" hints
+func Suggest(text string, names []string) (string, bool) {
+	matches := Rank(text, names)
+	if len(matches) == 0 || matches[0].Kind == Exact {
+		return "", false
+	}
+	return matches[0].Name, true
+}
+
+// closest is the distance from text to a name, or to the nearest of
+// its hyphenated words when the text has none: pikchu is a typo of
+// pikachu-gmax as well
+func closest(text, name string) int {
+	d := Distance(text, name)
+	if strings.Contains(text, "-") {
+		return d
+	}
+	for _, word := range strings.Split(name, "-") {
+		d = min(d, Distance(text, word))
+	}
+	return d
+}
+
+// Distance is the number of insertions, deletions, substitutions and
+// swaps of adjacent letters turning a into b
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// Three rows of the dynamic programming table are enough, the one
+	// before last is needed for swaps
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,74 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"pikachu", "pikachu", 0},
+		{"pikchu", "pikachu", 1},
+		{"pikahcu", "pikachu", 1},
+		{"pickachu", "pikachu", 1},
+		{"bulbasuar", "bulbasaur", 1},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+	}
+	for _, c := range cases {
+		if got := Distance(c.a, c.b); got != c.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"Great Ball":   "great-ball",
+		" mr  mime ":   "mr-mime",
+		"poke-ball":    "poke-ball",
+		"route--201 -": "route-201",
+	}
+	for in, want := range cases {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	names := []string{"pikachu", "pikachu-gmax", "raichu", "pichu", "poke-ball", "great-ball", "charmander"}
+	cases := []struct {
+		text string
+		want []Match
+	}{
+		{"pikachu", []Match{{"pikachu", Exact, 0}, {"pikachu-gmax", Prefix, 0}, {"pichu", Typo, 2}}},
+		{"chu", []Match{{"pichu", Contains, 0}, {"raichu", Contains, 0}, {"pikachu", Contains, 0}, {"pikachu-gmax", Contains, 0}}},
+		{"pikchu", []Match{{"pichu", Typo, 1}, {"pikachu", Typo, 1}, {"pikachu-gmax", Typo, 1}}},
+		{"Great Ball", []Match{{"great-ball", Exact, 0}}},
+		{"charmandr", []Match{{"charmander", Typo, 1}}},
+		{"xyz", []Match{}},
+		{"", nil},
+	}
+	for _, c := range cases {
+		if got := Rank(c.text, names); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Rank(%q) = %v, want %v", c.text, got, c.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	names := []string{"pikachu", "raichu", "bulbasaur"}
+	if got, ok := Suggest("bulbasuar", names); !ok || got != "bulbasaur" {
+		t.Errorf("Suggest(bulbasuar) = %q, %v, want bulbasaur", got, ok)
+	}
+	if _, ok := Suggest("pikachu", names); ok {
+		t.Error("expected no suggestion for an exact match")
+	}
+	if _, ok := Suggest("missingno", names); ok {
+		t.Error("expected no suggestion without a close name")
+	}
+}
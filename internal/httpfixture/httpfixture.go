@@ -0,0 +1,157 @@
+// Package httpfixture records HTTP traffic into fixture files and
+// replays it, so tests can run against realistic PokéAPI payloads
+// without touching the network.
+package httpfixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Fixture is a recorded response as stored on disk.
+// Body holds the payload when it is valid JSON, Text otherwise.
+type Fixture struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Text   string          `json:"text,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that forwards requests to Transport
+// and writes every response into Dir.
+type Recorder struct {
+	Dir       string
+	Transport http.RoundTripper
+}
+
+// NewRecorder creates a Recorder saving fixtures into dir. A nil
+// transport uses http.DefaultTransport.
+func NewRecorder(dir string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{Dir: dir, Transport: transport}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	dat, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := Fixture{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Header: http.Header{},
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		fixture.Header.Set("Content-Type", ct)
+	}
+	if json.Valid(dat) {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, dat, "", "  "); err != nil {
+			return nil, err
+		}
+		fixture.Body = indented.Bytes()
+	} else {
+		fixture.Text = string(dat)
+	}
+
+	if err := writeFixture(filepath.Join(r.Dir, Name(req)), fixture); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %w", req.URL, err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(dat))
+	return resp, nil
+}
+
+// Replayer is an http.RoundTripper serving responses from the fixtures
+// in Dir. Requests without a fixture fail instead of going online.
+type Replayer struct {
+	Dir string
+}
+
+// NewReplayer creates a Replayer reading fixtures from dir
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{Dir: dir}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(r.Dir, Name(req))
+	dat, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no fixture for %s %s (expected %s)", req.Method, req.URL, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(dat, &fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+
+	body := []byte(fixture.Text)
+	if len(fixture.Body) > 0 {
+		body = fixture.Body
+	}
+	header := fixture.Header
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Status, http.StatusText(fixture.Status)),
+		StatusCode:    fixture.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Name returns the fixture file name for a request, derived from its
+// method, path and sorted query, e.g.
+// GET https://pokeapi.co/api/v2/location-area?offset=20&limit=20
+// becomes get_api_v2_location-area__limit=20&offset=20.json
+func Name(req *http.Request) string {
+	path := strings.Trim(req.URL.Path, "/")
+	name := strings.ToLower(req.Method) + "_" + strings.ReplaceAll(path, "/", "_")
+	if query := req.URL.Query(); len(query) > 0 {
+		name += "__" + query.Encode()
+	}
+	return name + ".json"
+}
+
+// writeFixture stores a fixture as indented JSON
+func writeFixture(path string, fixture Fixture) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	dat, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(dat, '\n'), 0o644)
+}
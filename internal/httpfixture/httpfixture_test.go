@@ -0,0 +1,74 @@
+package httpfixture
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pokemon/pikachu" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":25,"name":"pikachu"}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder := &http.Client{Transport: NewRecorder(dir, nil)}
+	for _, path := range []string{"/pokemon/pikachu", "/pokemon/missingno"} {
+		resp, err := recorder.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("expected no error recording %s, got %v", path, err)
+		}
+		resp.Body.Close()
+	}
+	server.Close()
+
+	replayer := &http.Client{Transport: NewReplayer(dir)}
+
+	resp, err := replayer.Get(server.URL + "/pokemon/pikachu")
+	if err != nil {
+		t.Fatalf("expected no error replaying, got %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected content type to be replayed, got %q", resp.Header.Get("Content-Type"))
+	}
+	var pokemon struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &pokemon); err != nil || pokemon.ID != 25 || pokemon.Name != "pikachu" {
+		t.Errorf("unexpected body %q", body)
+	}
+
+	resp, err = replayer.Get(server.URL + "/pokemon/missingno")
+	if err != nil {
+		t.Fatalf("expected no error replaying, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected recorded 404, got %d", resp.StatusCode)
+	}
+
+	if _, err := replayer.Get(server.URL + "/pokemon/unrecorded"); err == nil {
+		t.Error("expected an error for a missing fixture")
+	}
+}
+
+func TestName(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://pokeapi.co/api/v2/location-area?offset=20&limit=20", nil)
+	if got := Name(req); got != "get_api_v2_location-area__limit=20&offset=20.json" {
+		t.Errorf("unexpected fixture name %q", got)
+	}
+}
@@ -0,0 +1,123 @@
+// Package inventory keeps the trainer's bag: the items they carry and
+// their money.
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	// StartingMoney is the money of a new trainer
+	StartingMoney = 3000
+	// MaxMoney is the most money a trainer can hold, as in the games
+	MaxMoney = 999999
+	// MaxStack is the most of a single item the bag can hold
+	MaxStack = 999
+)
+
+// StartingItems are the items of a new trainer
+var StartingItems = map[string]int{"poke-ball": 5}
+
+var (
+	// ErrNotEnoughMoney is returned when buying more than the trainer
+	// can afford
+	ErrNotEnoughMoney = errors.New("not enough money")
+	// ErrNotEnoughItems is returned when taking out more of an item
+	// than the bag holds
+	ErrNotEnoughItems = errors.New("not enough items")
+	// ErrBagFull is returned when an item would go past MaxStack
+	ErrBagFull = errors.New("the bag can't hold that many")
+)
+
+// Bag holds the trainer's items by item name, and their money
+type Bag struct {
+	Money int            `json:"money"`
+	Items map[string]int `json:"items"`
+}
+
+// NewBag returns the bag of a new trainer
+func NewBag() *Bag {
+	b := &Bag{Money: StartingMoney, Items: map[string]int{}}
+	for item, n := range StartingItems {
+		b.Items[item] = n
+	}
+	return b
+}
+
+// Count returns how many of an item the bag holds
+func (b *Bag) Count(item string) int {
+	return b.Items[item]
+}
+
+// Names returns the names of the items in the bag, sorted
+func (b *Bag) Names() []string {
+	names := make([]string, 0, len(b.Items))
+	for item := range b.Items {
+		names = append(names, item)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add puts n of an item in the bag
+func (b *Bag) Add(item string, n int) error {
+	if n < 1 {
+		return fmt.Errorf("invalid quantity %d", n)
+	}
+	if b.Items[item]+n > MaxStack {
+		return fmt.Errorf("%w: at most %d %s", ErrBagFull, MaxStack, item)
+	}
+	if b.Items == nil {
+		b.Items = map[string]int{}
+	}
+	b.Items[item] += n
+	return nil
+}
+
+// Remove takes n of an item out of the bag
+func (b *Bag) Remove(item string, n int) error {
+	if n < 1 {
+		return fmt.Errorf("invalid quantity %d", n)
+	}
+	if b.Items[item] < n {
+		return fmt.Errorf("%w: you have %d %s", ErrNotEnoughItems, b.Items[item], item)
+	}
+	b.Items[item] -= n
+	if b.Items[item] == 0 {
+		delete(b.Items, item)
+	}
+	return nil
+}
+
+// Earn adds money, up to MaxMoney
+func (b *Bag) Earn(amount int) {
+	b.Money = min(b.Money+amount, MaxMoney)
+}
+
+// Buy pays for n of an item at price each and puts them in the bag
+func (b *Bag) Buy(item string, n, price int) error {
+	if n < 1 {
+		return fmt.Errorf("invalid quantity %d", n)
+	}
+	if b.Money < n*price {
+		return fmt.Errorf("%w: %d %s cost ₽%d, you have ₽%d", ErrNotEnoughMoney, n, item, n*price, b.Money)
+	}
+	if err := b.Add(item, n); err != nil {
+		return err
+	}
+	b.Money -= n * price
+	return nil
+}
+
+// Sell takes n of an item out of the bag for half their price each,
+// as shops do, and returns the money earned
+func (b *Bag) Sell(item string, n, price int) (int, error) {
+	if err := b.Remove(item, n); err != nil {
+		return 0, err
+	}
+	earned := n * (price / 2)
+	b.Earn(earned)
+	return earned, nil
+}
@@ -0,0 +1,70 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewBag(t *testing.T) {
+	b := NewBag()
+	if b.Money != StartingMoney || b.Count("poke-ball") != 5 {
+		t.Errorf("NewBag() = %+v, want ₽%d and 5 poke-balls", b, StartingMoney)
+	}
+
+	// Bags don't share the starting items
+	b.Items["poke-ball"] = 1
+	if NewBag().Count("poke-ball") != 5 {
+		t.Error("changing a bag changed the starting items")
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	b := &Bag{}
+	if err := b.Add("potion", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Add("potion", 0); err == nil {
+		t.Error("Add(0) didn't fail")
+	}
+	if err := b.Add("potion", MaxStack); !errors.Is(err, ErrBagFull) {
+		t.Errorf("Add() past MaxStack = %v, want ErrBagFull", err)
+	}
+	if err := b.Remove("potion", 3); !errors.Is(err, ErrNotEnoughItems) {
+		t.Errorf("Remove(3) = %v, want ErrNotEnoughItems", err)
+	}
+	if err := b.Remove("potion", 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := b.Items["potion"]; ok || len(b.Names()) != 0 {
+		t.Errorf("items = %v, want none left", b.Items)
+	}
+}
+
+func TestBuySell(t *testing.T) {
+	b := NewBag()
+	if err := b.Buy("ultra-ball", 4, 800); !errors.Is(err, ErrNotEnoughMoney) {
+		t.Errorf("Buy() = %v, want ErrNotEnoughMoney", err)
+	}
+	if err := b.Buy("ultra-ball", 3, 800); err != nil {
+		t.Fatal(err)
+	}
+	if b.Money != 600 || b.Count("ultra-ball") != 3 {
+		t.Errorf("bag = %+v, want ₽600 and 3 ultra-balls", b)
+	}
+
+	earned, err := b.Sell("ultra-ball", 2, 800)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if earned != 800 || b.Money != 1400 || b.Count("ultra-ball") != 1 {
+		t.Errorf("sold for ₽%d, bag = %+v, want ₽800 and ₽1400 left", earned, b)
+	}
+	if _, err := b.Sell("master-ball", 1, 0); !errors.Is(err, ErrNotEnoughItems) {
+		t.Errorf("Sell() = %v, want ErrNotEnoughItems", err)
+	}
+
+	b.Earn(MaxMoney)
+	if b.Money != MaxMoney {
+		t.Errorf("money = %d, want it capped at %d", b.Money, MaxMoney)
+	}
+}
@@ -0,0 +1,72 @@
+package owned
+
+// Nature raises one stat by 10% and lowers another by 10%. Neutral
+// natures raise and lower the same stat, which cancels out.
+type Nature struct {
+	Name      string
+	Increased string
+	Decreased string
+}
+
+// Natures lists the 25 natures in the order of the games
+var Natures = []Nature{
+	{"hardy", "attack", "attack"},
+	{"lonely", "attack", "defense"},
+	{"brave", "attack", "speed"},
+	{"adamant", "attack", "special-attack"},
+	{"naughty", "attack", "special-defense"},
+	{"bold", "defense", "attack"},
+	{"docile", "defense", "defense"},
+	{"relaxed", "defense", "speed"},
+	{"impish", "defense", "special-attack"},
+	{"lax", "defense", "special-defense"},
+	{"timid", "speed", "attack"},
+	{"hasty", "speed", "defense"},
+	{"serious", "speed", "speed"},
+	{"jolly", "speed", "special-attack"},
+	{"naive", "speed", "special-defense"},
+	{"modest", "special-attack", "attack"},
+	{"mild", "special-attack", "defense"},
+	{"quiet", "special-attack", "speed"},
+	{"bashful", "special-attack", "special-attack"},
+	{"rash", "special-attack", "special-defense"},
+	{"calm", "special-defense", "attack"},
+	{"gentle", "special-defense", "defense"},
+	{"sassy", "special-defense", "speed"},
+	{"careful", "special-defense", "special-attack"},
+	{"quirky", "special-defense", "special-defense"},
+}
+
+// NatureByName looks a nature up by name
+func NatureByName(name string) (Nature, bool) {
+	for _, n := range Natures {
+		if n.Name == name {
+			return n, true
+		}
+	}
+	return Nature{}, false
+}
+
+// Neutral reports whether the nature has no effect on stats
+func (n Nature) Neutral() bool {
+	return n.Increased == n.Decreased
+}
+
+// Modifier returns the multiplier the nature applies to a stat
+func (n Nature) Modifier(stat string) float64 {
+	return float64(n.percent(stat)) / 100
+}
+
+// percent is the modifier as a percentage, so the stat formula can
+// stay in integers like the games
+func (n Nature) percent(stat string) int {
+	switch {
+	case n.Neutral():
+		return 100
+	case stat == n.Increased:
+		return 110
+	case stat == n.Decreased:
+		return 90
+	}
+	return 100
+}
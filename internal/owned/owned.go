@@ -0,0 +1,189 @@
+// Package owned models the Pokémon a trainer owns: unlike a species,
+// each one has its own level, nature, individual values (IVs) and
+// effort values (EVs), from which its stats are computed with the
+// formula of the games since Generation III.
+package owned
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// MaxIV is the highest individual value of a stat
+const MaxIV = 31
+
+// MaxEV is the highest effort value of a stat, and MaxTotalEVs the
+// highest sum over all stats
+const (
+	MaxEV       = 252
+	MaxTotalEVs = 510
+)
+
+// MaxLevel is the highest level a Pokémon can reach
+const MaxLevel = 100
+
+// MaxNicknameLength is the longest nickname a Pokémon can be given
+const MaxNicknameLength = 12
+
+// ShinyOdds is the 1 in ShinyOdds chance of a Pokémon being shiny
+const ShinyOdds = 4096
+
+// StatNames lists the stats in the order of the PokéAPI
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// Stats holds a value for each stat
+type Stats struct {
+	HP             int `json:"hp"`
+	Attack         int `json:"attack"`
+	Defense        int `json:"defense"`
+	SpecialAttack  int `json:"special_attack"`
+	SpecialDefense int `json:"special_defense"`
+	Speed          int `json:"speed"`
+}
+
+// Get returns the value of a stat by its PokéAPI name
+func (s Stats) Get(stat string) int {
+	switch stat {
+	case "hp":
+		return s.HP
+	case "attack":
+		return s.Attack
+	case "defense":
+		return s.Defense
+	case "special-attack":
+		return s.SpecialAttack
+	case "special-defense":
+		return s.SpecialDefense
+	case "speed":
+		return s.Speed
+	}
+	return 0
+}
+
+// Set changes the value of a stat by its PokéAPI name
+func (s *Stats) Set(stat string, value int) {
+	switch stat {
+	case "hp":
+		s.HP = value
+	case "attack":
+		s.Attack = value
+	case "defense":
+		s.Defense = value
+	case "special-attack":
+		s.SpecialAttack = value
+	case "special-defense":
+		s.SpecialDefense = value
+	case "speed":
+		s.Speed = value
+	}
+}
+
+// Total returns the sum of all stats
+func (s Stats) Total() int {
+	return s.HP + s.Attack + s.Defense + s.SpecialAttack + s.SpecialDefense + s.Speed
+}
+
+// BaseStats reads the base stats of a Pokémon
+func BaseStats(details pokeapi.PokemonDetails) Stats {
+	base := Stats{}
+	for _, stat := range details.Stats {
+		base.Set(stat.Stat.Name, stat.BaseStat)
+	}
+	return base
+}
+
+// Pokemon is a single Pokémon owned by the trainer.
+// Species is the PokéAPI pokemon name, which changes on evolution.
+// Exp is its total experience points.
+// Moves are the names of the moves it knows, at most four.
+// CaughtAt is the location area it was caught in, empty if unknown.
+type Pokemon struct {
+	ID         int       `json:"id"`
+	Species    string    `json:"species"`
+	Nickname   string    `json:"nickname,omitempty"`
+	Level      int       `json:"level"`
+	Exp        int       `json:"exp"`
+	Nature     string    `json:"nature"`
+	Gender     string    `json:"gender"`
+	Shiny      bool      `json:"shiny"`
+	IVs        Stats     `json:"ivs"`
+	EVs        Stats     `json:"evs"`
+	Moves      []string  `json:"moves,omitempty"`
+	CaughtAt   string    `json:"caught_at"`
+	CaughtTime time.Time `json:"caught_time"`
+}
+
+// New rolls a freshly caught Pokémon: a random nature, gender, IVs
+// and EVs, and the small chance of being shiny. The EVs stay within
+// MaxEV per stat and MaxTotalEVs overall. genderRate is the species
+// gender_rate, the chance of being female in eighths or -1 when
+// genderless.
+func New(rng *rand.Rand, id int, species string, level int, genderRate int) Pokemon {
+	p := Pokemon{
+		ID:      id,
+		Species: species,
+		Level:   min(max(level, 1), MaxLevel),
+		Nature:  Natures[rng.Intn(len(Natures))].Name,
+		Shiny:   rng.Intn(ShinyOdds) == 0,
+	}
+
+	switch {
+	case genderRate < 0:
+		p.Gender = "genderless"
+	case rng.Intn(8) < genderRate:
+		p.Gender = "female"
+	default:
+		p.Gender = "male"
+	}
+
+	for _, stat := range StatNames {
+		p.IVs.Set(stat, rng.Intn(MaxIV+1))
+	}
+	// Stats are filled in a random order so none of them is favoured
+	// by getting the first share of the total
+	remaining := rng.Intn(MaxTotalEVs + 1)
+	for _, i := range rng.Perm(len(StatNames)) {
+		ev := rng.Intn(min(remaining, MaxEV) + 1)
+		p.EVs.Set(StatNames[i], ev)
+		remaining -= ev
+	}
+	return p
+}
+
+// Name returns the nickname of the Pokémon, or its species without one
+func (p Pokemon) Name() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.Species
+}
+
+// Stats computes the actual stats of the Pokémon from its base stats
+func (p Pokemon) Stats(base Stats) Stats {
+	nature, _ := NatureByName(p.Nature)
+	stats := Stats{}
+	for _, stat := range StatNames {
+		stats.Set(stat, CalcStat(stat, base.Get(stat), p.IVs.Get(stat), p.EVs.Get(stat), p.Level, nature))
+	}
+	return stats
+}
+
+// CalcStat applies the stat formula of the games:
+//
+//	HP    = (2*Base + IV + EV/4) * Level/100 + Level + 10
+//	other = ((2*Base + IV + EV/4) * Level/100 + 5) * Nature
+//
+// rounding down after every step.
+func CalcStat(stat string, base, iv, ev, level int, nature Nature) int {
+	value := (2*base + iv + ev/4) * level / 100
+	if stat == "hp" {
+		// Shedinja's single hit point is its base stat
+		if base == 1 {
+			return 1
+		}
+		return value + level + 10
+	}
+	return (value + 5) * nature.percent(stat) / 100
+}
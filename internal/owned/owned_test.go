@@ -0,0 +1,171 @@
+package owned
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	// The example from Bulbapedia's stat article: a level 78 Garchomp
+	// with an adamant nature
+	garchomp := Pokemon{
+		Level:  78,
+		Nature: "adamant",
+		IVs:    Stats{HP: 24, Attack: 12, Defense: 30, SpecialAttack: 16, SpecialDefense: 23, Speed: 5},
+		EVs:    Stats{HP: 74, Attack: 190, Defense: 91, SpecialAttack: 48, SpecialDefense: 84, Speed: 23},
+	}
+	base := Stats{HP: 108, Attack: 130, Defense: 95, SpecialAttack: 80, SpecialDefense: 85, Speed: 102}
+
+	expected := Stats{HP: 289, Attack: 278, Defense: 193, SpecialAttack: 135, SpecialDefense: 171, Speed: 171}
+	if got := garchomp.Stats(base); got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestCalcStatShedinja(t *testing.T) {
+	if hp := CalcStat("hp", 1, 31, 252, 100, Nature{}); hp != 1 {
+		t.Errorf("expected shedinja to have 1 hp, got %d", hp)
+	}
+}
+
+func TestNatures(t *testing.T) {
+	if len(Natures) != 25 {
+		t.Fatalf("expected 25 natures, got %d", len(Natures))
+	}
+	neutral := 0
+	for _, n := range Natures {
+		if n.Neutral() {
+			neutral++
+		}
+	}
+	if neutral != 5 {
+		t.Errorf("expected 5 neutral natures, got %d", neutral)
+	}
+
+	modest, _ := NatureByName("modest")
+	if modest.Modifier("special-attack") != 1.1 || modest.Modifier("attack") != 0.9 || modest.Modifier("speed") != 1 {
+		t.Errorf("unexpected modest modifiers")
+	}
+	if _, found := NatureByName("grumpy"); found {
+		t.Error("expected no grumpy nature")
+	}
+}
+
+func TestNew(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	females, evs := 0, 0
+	for i := 0; i < 1000; i++ {
+		p := New(rng, i, "pikachu", 5, 4)
+		if p.ID != i || p.Species != "pikachu" || p.Level != 5 {
+			t.Fatalf("unexpected pokemon %+v", p)
+		}
+		if _, found := NatureByName(p.Nature); !found {
+			t.Fatalf("unknown nature %q", p.Nature)
+		}
+		for _, stat := range StatNames {
+			if iv := p.IVs.Get(stat); iv < 0 || iv > MaxIV {
+				t.Fatalf("expected %s IV within 0-%d, got %d", stat, MaxIV, iv)
+			}
+		}
+		for _, stat := range StatNames {
+			if ev := p.EVs.Get(stat); ev < 0 || ev > MaxEV {
+				t.Fatalf("expected %s EV within 0-%d, got %d", stat, MaxEV, ev)
+			}
+		}
+		if p.EVs.Total() > MaxTotalEVs {
+			t.Fatalf("expected at most %d EVs, got %+v", MaxTotalEVs, p.EVs)
+		}
+		evs += p.EVs.Total()
+		if p.Gender == "female" {
+			females++
+		}
+	}
+	if females < 400 || females > 600 {
+		t.Errorf("expected about half females with a gender rate of 4, got %d", females)
+	}
+	if evs == 0 {
+		t.Error("expected some pokemon to have EVs")
+	}
+
+	if p := New(rng, 0, "magnemite", 200, -1); p.Gender != "genderless" || p.Level != MaxLevel {
+		t.Errorf("expected a genderless level %d pokemon, got %+v", MaxLevel, p)
+	}
+}
+
+func TestStorage(t *testing.T) {
+	s := NewStorage()
+	for i := 0; i < PartySize+2; i++ {
+		p, place, err := s.Add(Pokemon{Species: "bidoof"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if p.ID != i+1 {
+			t.Errorf("expected id %d, got %d", i+1, p.ID)
+		}
+		if i < PartySize && place.Box != 0 || i >= PartySize && place.Box != 1 {
+			t.Errorf("expected catch %d to go to the party then box 1, got %+v", i+1, place)
+		}
+	}
+	if len(s.All()) != PartySize+2 {
+		t.Fatalf("expected %d pokemon, got %d", PartySize+2, len(s.All()))
+	}
+
+	if err := s.Withdraw(7); err == nil {
+		t.Error("expected an error withdrawing into a full party")
+	}
+	if box, err := s.Deposit(1, 3); err != nil || box != 3 {
+		t.Fatalf("expected #1 to go to box 3, got %d, %v", box, err)
+	}
+	if err := s.Withdraw(7); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if place, _ := s.Find(7); place != (Place{Box: 0, Slot: 5}) {
+		t.Errorf("expected #7 at the end of the party, got %+v", place)
+	}
+
+	if err := s.Swap(2, 8); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if place, _ := s.Find(8); place != (Place{Box: 0, Slot: 0}) {
+		t.Errorf("expected #8 to take the first party slot, got %+v", place)
+	}
+	if place, _ := s.Find(2); place != (Place{Box: 1, Slot: 0}) {
+		t.Errorf("expected #2 to go to box 1, got %+v", place)
+	}
+
+	if _, err := s.Release(2); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, found := s.Find(2); found {
+		t.Error("expected #2 to be gone")
+	}
+	if _, err := s.Box(BoxCount + 1); err == nil {
+		t.Error("expected an error for a box past the last one")
+	}
+}
+
+func TestStorageKeepsOnePartyMember(t *testing.T) {
+	s := NewStorage()
+	s.Add(Pokemon{Species: "pikachu"})
+	if _, err := s.Deposit(1, 0); err == nil {
+		t.Error("expected an error depositing the last party pokemon")
+	}
+	if _, err := s.Release(1); err == nil {
+		t.Error("expected an error releasing the last party pokemon")
+	}
+}
+
+func TestStorageFull(t *testing.T) {
+	s := NewStorage()
+	for i := 0; i < PartySize+BoxCount*BoxSize; i++ {
+		if _, _, err := s.Add(Pokemon{}); err != nil {
+			t.Fatalf("expected room for pokemon %d, got %v", i+1, err)
+		}
+	}
+	if !s.Full() {
+		t.Error("expected the storage to be full")
+	}
+	if _, _, err := s.Add(Pokemon{}); err != ErrStorageFull {
+		t.Errorf("expected ErrStorageFull, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package owned
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// PartySize is the number of Pokémon a trainer can carry
+const PartySize = 6
+
+// BoxCount and BoxSize are the number of PC boxes and their capacity,
+// as in Diamond and Pearl
+const (
+	BoxCount = 18
+	BoxSize  = 30
+)
+
+// ErrStorageFull is returned when neither the party nor any box has room
+var ErrStorageFull = errors.New("the party and every box are full")
+
+// Storage holds every owned Pokémon, either in the party or in a PC
+// box. LastID is the id given to the latest Pokémon.
+type Storage struct {
+	Party  []*Pokemon   `json:"party"`
+	Boxes  [][]*Pokemon `json:"boxes"`
+	LastID int          `json:"last_id"`
+}
+
+// Place tells where a Pokémon is stored: Box is 0 for the party and
+// 1-based otherwise, Slot is always 0-based.
+type Place struct {
+	Box  int
+	Slot int
+}
+
+// NewStorage returns an empty party and empty boxes
+func NewStorage() *Storage {
+	return &Storage{Party: []*Pokemon{}, Boxes: make([][]*Pokemon, BoxCount)}
+}
+
+// All returns the party followed by the content of each box
+func (s *Storage) All() []*Pokemon {
+	all := slices.Clone(s.Party)
+	for _, box := range s.Boxes {
+		all = append(all, box...)
+	}
+	return all
+}
+
+// Full reports whether there is no room left for another Pokémon
+func (s *Storage) Full() bool {
+	_, found := s.freeBox()
+	return len(s.Party) >= PartySize && !found
+}
+
+// Add gives p a new id and stores it in the party, or in the first box
+// with room when the party is full, like the games do with catches
+func (s *Storage) Add(p Pokemon) (*Pokemon, Place, error) {
+	place := Place{}
+	if len(s.Party) >= PartySize {
+		box, found := s.freeBox()
+		if !found {
+			return nil, Place{}, ErrStorageFull
+		}
+		place.Box = box
+	}
+
+	s.LastID++
+	p.ID = s.LastID
+	stored := &p
+	if place.Box == 0 {
+		place.Slot = len(s.Party)
+		s.Party = append(s.Party, stored)
+	} else {
+		place.Slot = len(s.Boxes[place.Box-1])
+		s.Boxes[place.Box-1] = append(s.Boxes[place.Box-1], stored)
+	}
+	return stored, place, nil
+}
+
+// Find returns where the Pokémon with the given id is stored
+func (s *Storage) Find(id int) (Place, bool) {
+	if i := slices.IndexFunc(s.Party, hasID(id)); i >= 0 {
+		return Place{Slot: i}, true
+	}
+	for b, box := range s.Boxes {
+		if i := slices.IndexFunc(box, hasID(id)); i >= 0 {
+			return Place{Box: b + 1, Slot: i}, true
+		}
+	}
+	return Place{}, false
+}
+
+// Box returns the content of a 1-based box
+func (s *Storage) Box(n int) ([]*Pokemon, error) {
+	if n < 1 || n > len(s.Boxes) {
+		return nil, fmt.Errorf("there is no box %d, boxes go from 1 to %d", n, len(s.Boxes))
+	}
+	return s.Boxes[n-1], nil
+}
+
+// Deposit moves a party Pokémon to a box, or to the first box with
+// room when box is 0. The last Pokémon of the party can't be deposited.
+func (s *Storage) Deposit(id int, box int) (int, error) {
+	place, found := s.Find(id)
+	if !found {
+		return 0, fmt.Errorf("no pokemon with id %d", id)
+	}
+	if place.Box != 0 {
+		return 0, fmt.Errorf("#%d is already in box %d", id, place.Box)
+	}
+	if len(s.Party) == 1 {
+		return 0, errors.New("you can't deposit your last party pokemon")
+	}
+
+	if box == 0 {
+		var found bool
+		if box, found = s.freeBox(); !found {
+			return 0, errors.New("every box is full")
+		}
+	}
+	content, err := s.Box(box)
+	if err != nil {
+		return 0, err
+	}
+	if len(content) >= BoxSize {
+		return 0, fmt.Errorf("box %d is full", box)
+	}
+
+	p := s.Party[place.Slot]
+	s.Party = slices.Delete(s.Party, place.Slot, place.Slot+1)
+	s.Boxes[box-1] = append(content, p)
+	return box, nil
+}
+
+// Withdraw moves a boxed Pokémon to the end of the party
+func (s *Storage) Withdraw(id int) error {
+	place, found := s.Find(id)
+	if !found {
+		return fmt.Errorf("no pokemon with id %d", id)
+	}
+	if place.Box == 0 {
+		return fmt.Errorf("#%d is already in your party", id)
+	}
+	if len(s.Party) >= PartySize {
+		return errors.New("your party is full")
+	}
+
+	box := s.Boxes[place.Box-1]
+	s.Party = append(s.Party, box[place.Slot])
+	s.Boxes[place.Box-1] = slices.Delete(box, place.Slot, place.Slot+1)
+	return nil
+}
+
+// Swap exchanges the places of two Pokémon, which reorders the party
+// or trades a party member for a boxed one
+func (s *Storage) Swap(a, b int) error {
+	placeA, found := s.Find(a)
+	if !found {
+		return fmt.Errorf("no pokemon with id %d", a)
+	}
+	placeB, found := s.Find(b)
+	if !found {
+		return fmt.Errorf("no pokemon with id %d", b)
+	}
+
+	slotA, slotB := s.slot(placeA), s.slot(placeB)
+	*slotA, *slotB = *slotB, *slotA
+	return nil
+}
+
+// Release removes a Pokémon for good. The last Pokémon of the party
+// can't be released.
+func (s *Storage) Release(id int) (*Pokemon, error) {
+	place, found := s.Find(id)
+	if !found {
+		return nil, fmt.Errorf("no pokemon with id %d", id)
+	}
+
+	p := *s.slot(place)
+	if place.Box == 0 {
+		if len(s.Party) == 1 {
+			return nil, errors.New("you can't release your last party pokemon")
+		}
+		s.Party = slices.Delete(s.Party, place.Slot, place.Slot+1)
+	} else {
+		s.Boxes[place.Box-1] = slices.Delete(s.Boxes[place.Box-1], place.Slot, place.Slot+1)
+	}
+	return p, nil
+}
+
+// slot returns a pointer to the storage slot at place
+func (s *Storage) slot(place Place) **Pokemon {
+	if place.Box == 0 {
+		return &s.Party[place.Slot]
+	}
+	return &s.Boxes[place.Box-1][place.Slot]
+}
+
+// freeBox returns the first box with room
+func (s *Storage) freeBox() (int, bool) {
+	for i, box := range s.Boxes {
+		if len(box) < BoxSize {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// hasID matches Pokémon by id
+func hasID(id int) func(*Pokemon) bool {
+	return func(p *Pokemon) bool { return p.ID == id }
+}
@@ -0,0 +1,56 @@
+package pokeapi
+
+import "fmt"
+
+// When calling https://pokeapi.co/api/v2/ability/{name}/
+type Ability struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	IsMainSeries  bool             `json:"is_main_series"`
+	Generation    NamedAPIResource `json:"generation"`
+	EffectEntries []struct {
+		Effect      string           `json:"effect"`
+		ShortEffect string           `json:"short_effect"`
+		Language    NamedAPIResource `json:"language"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText   string           `json:"flavor_text"`
+		Language     NamedAPIResource `json:"language"`
+		VersionGroup NamedAPIResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	Pokemon []struct {
+		IsHidden bool             `json:"is_hidden"`
+		Slot     int              `json:"slot"`
+		Pokemon  NamedAPIResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+// FetchAbility fetches the details of an ability
+func (c *Client) FetchAbility(name string) (Ability, error) {
+	url := fmt.Sprintf("%s/ability/%s", c.baseURL, name)
+	ability, err := getJSON[Ability](c, url, staticTTL)
+	if err != nil {
+		return Ability{}, fmt.Errorf("failed to fetch ability: %w", err)
+	}
+	return ability, nil
+}
+
+// Effect returns the effect and short effect texts in the given
+// language. Abilities of recent generations often have no effect
+// entries, so the latest flavor text is used as the short effect
+// instead.
+func (a Ability) Effect(language string) (effect string, short string, ok bool) {
+	for _, entry := range a.EffectEntries {
+		if entry.Language.Name == language {
+			return entry.Effect, entry.ShortEffect, true
+		}
+	}
+	for i := len(a.FlavorTextEntries) - 1; i >= 0; i-- {
+		entry := a.FlavorTextEntries[i]
+		if entry.Language.Name == language {
+			text := cleanFlavorText(entry.FlavorText)
+			return text, text, true
+		}
+	}
+	return "", "", false
+}
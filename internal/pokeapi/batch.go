@@ -0,0 +1,50 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FetchConcurrently calls fetch for every name using at most workers
+// goroutines. It returns the successful results keyed by name along
+// with every failure joined into a single error.
+func FetchConcurrently[T any](names []string, workers int, fetch func(string) (T, error)) (map[string]T, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		errs    []error
+		results = make(map[string]T, len(names))
+		jobs    = make(chan string)
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				val, err := fetch(name)
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				} else {
+					results[name] = val
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, name := range names {
+		jobs <- name
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
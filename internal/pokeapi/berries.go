@@ -0,0 +1,84 @@
+package pokeapi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// berryWorkers bounds the concurrent requests of FetchAllBerries
+const berryWorkers = 8
+
+// When calling https://pokeapi.co/api/v2/berry/{name}/
+// GrowthTime is the number of hours per growth stage.
+type Berry struct {
+	ID               int              `json:"id"`
+	Name             string           `json:"name"`
+	GrowthTime       int              `json:"growth_time"`
+	MaxHarvest       int              `json:"max_harvest"`
+	NaturalGiftPower int              `json:"natural_gift_power"`
+	NaturalGiftType  NamedAPIResource `json:"natural_gift_type"`
+	Size             int              `json:"size"`
+	Smoothness       int              `json:"smoothness"`
+	SoilDryness      int              `json:"soil_dryness"`
+	Firmness         NamedAPIResource `json:"firmness"`
+	Flavors          []struct {
+		Potency int              `json:"potency"`
+		Flavor  NamedAPIResource `json:"flavor"`
+	} `json:"flavors"`
+	Item NamedAPIResource `json:"item"`
+}
+
+// When calling https://pokeapi.co/api/v2/berry-flavor/{name}/
+type BerryFlavor struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Berries []struct {
+		Potency int              `json:"potency"`
+		Berry   NamedAPIResource `json:"berry"`
+	} `json:"berries"`
+	ContestType NamedAPIResource `json:"contest_type"`
+}
+
+// FetchBerry fetches the details of a berry
+func (c *Client) FetchBerry(name string) (Berry, error) {
+	url := fmt.Sprintf("%s/berry/%s", c.baseURL, name)
+	berry, err := getJSON[Berry](c, url, staticTTL)
+	if err != nil {
+		return Berry{}, fmt.Errorf("failed to fetch berry: %w", err)
+	}
+	return berry, nil
+}
+
+// FetchBerryFlavor fetches a flavor and the berries that have it
+func (c *Client) FetchBerryFlavor(name string) (BerryFlavor, error) {
+	url := fmt.Sprintf("%s/berry-flavor/%s", c.baseURL, name)
+	flavor, err := getJSON[BerryFlavor](c, url, staticTTL)
+	if err != nil {
+		return BerryFlavor{}, fmt.Errorf("failed to fetch berry flavor: %w", err)
+	}
+	return flavor, nil
+}
+
+// FetchAllBerries fetches every berry, ordered by id
+func (c *Client) FetchAllBerries() ([]Berry, error) {
+	list, err := c.ListResources("berry")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(list))
+	for _, b := range list {
+		names = append(names, b.Name)
+	}
+	fetched, err := FetchConcurrently(names, berryWorkers, c.FetchBerry)
+	if err != nil {
+		return nil, err
+	}
+
+	berries := make([]Berry, 0, len(fetched))
+	for _, b := range fetched {
+		berries = append(berries, b)
+	}
+	sort.Slice(berries, func(i, j int) bool { return berries[i].ID < berries[j].ID })
+	return berries, nil
+}
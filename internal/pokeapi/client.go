@@ -1,30 +1,155 @@
 package pokeapi
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/nurusanwe/pokedexcli/internal/pokecache"
 )
 
+// staticTTL is how long data that practically never changes (Pokémon,
+// species, areas...) is kept, while list pages use the client interval.
+const staticTTL = 72 * time.Hour
+
+// ErrNotFound is returned, wrapped, for resources the API doesn't have
+var ErrNotFound = errors.New("not found")
+
 // Client wraps the details needed to access the PokéAPI.
 // The httpClient field performs HTTP requests.
-// The cache stores responses to limit network calls.
+// The cache stores raw responses to limit network calls.
+// The objects cache keeps the decoded structs of hot entries.
 // The baseURL holds the root API endpoint.
 type Client struct {
 	httpClient http.Client
 	cache      *pokecache.Cache
+	objects    *pokecache.TypedCache[string, any]
 	baseURL    string
 }
 
+// Option customises a Client created by NewClient
+type Option func(*Client)
+
+// WithTransport makes the client send requests through rt, e.g. to
+// record or replay fixtures.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WithBaseURL points the client at another PokéAPI compatible server
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
 // NewClient creates a Client with the given request timeout and
 // cache expiration duration.
-func NewClient(timeout time.Duration, cacheInterval time.Duration) Client {
-	return Client{
+func NewClient(timeout time.Duration, cacheInterval time.Duration, opts ...Option) Client {
+	c := Client{
 		httpClient: http.Client{
 			Timeout: timeout,
 		},
 		cache:   pokecache.NewCache(cacheInterval),
+		objects: pokecache.NewTypedCache[string, any](cacheInterval),
 		baseURL: "https://pokeapi.co/api/v2",
 	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// getJSON returns the resource at url decoded into T. Decoded values
+// are served first, then raw cached bodies, then the network. Both
+// caches keep the entry for ttl.
+func getJSON[T any](c *Client, url string, ttl time.Duration) (T, error) {
+	var zero T
+
+	if c.objects != nil {
+		if obj, found := c.objects.Get(url); found {
+			if val, ok := obj.(T); ok {
+				return val, nil
+			}
+		}
+	}
+
+	if c.cache != nil {
+		if cachedData, found := c.cache.Get(url); found {
+			var val T
+			if err := json.Unmarshal(cachedData, &val); err == nil {
+				c.storeObject(url, val, ttl)
+				return val, nil
+			}
+		}
+	}
+
+	dat, err := c.fetch(url)
+	if err != nil {
+		return zero, err
+	}
+
+	var val T
+	if err := json.Unmarshal(dat, &val); err != nil {
+		return zero, err
+	}
+
+	// Store response in cache
+	if c.cache != nil {
+		c.cache.AddWithTTL(url, dat, ttl)
+	}
+	c.storeObject(url, val, ttl)
+
+	return val, nil
+}
+
+// fetch performs a GET request and returns the body of a 200 response
+func (c *Client) fetch(url string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("status code %d: %w", resp.StatusCode, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// storeObject keeps a decoded value in the objects cache if there is one
+func (c *Client) storeObject(url string, val any, ttl time.Duration) {
+	if c.objects != nil {
+		c.objects.AddWithTTL(url, val, ttl)
+	}
+}
+
+// CacheStats reports the size and compression of the response cache
+func (c *Client) CacheStats() pokecache.Stats {
+	return c.cache.Stats()
+}
+
+// SaveCache writes the raw response cache to path
+func (c *Client) SaveCache(path string) error {
+	return c.cache.Save(path)
+}
+
+// LoadCache fills the raw response cache from a file written by SaveCache
+func (c *Client) LoadCache(path string) error {
+	return c.cache.Load(path)
 }
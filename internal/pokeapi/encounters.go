@@ -0,0 +1,42 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Encounter is one way of meeting a Pokemon in a location area.
+// Chance is the percent chance of the encounter with this method.
+type Encounter struct {
+	MinLevel        int                `json:"min_level"`
+	MaxLevel        int                `json:"max_level"`
+	ConditionValues []NamedAPIResource `json:"condition_values"`
+	Chance          int                `json:"chance"`
+	Method          NamedAPIResource   `json:"method"`
+}
+
+// VersionEncounterDetail lists the encounters of a game version
+type VersionEncounterDetail struct {
+	Version          NamedAPIResource `json:"version"`
+	MaxChance        int              `json:"max_chance"`
+	EncounterDetails []Encounter      `json:"encounter_details"`
+}
+
+// When calling https://pokeapi.co/api/v2/pokemon/{id}/encounters
+type LocationAreaEncounter struct {
+	LocationArea   NamedAPIResource         `json:"location_area"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+// FetchLocationAreaEncounters fetches where a Pokemon can be found from
+// the URL in PokemonDetails.LocationAreaEncounters
+func (c *Client) FetchLocationAreaEncounters(url string) ([]LocationAreaEncounter, error) {
+	if url == "" {
+		return nil, errors.New("failed to fetch encounters: missing url")
+	}
+	encounters, err := getJSON[[]LocationAreaEncounter](c, url, staticTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch encounters: %w", err)
+	}
+	return encounters, nil
+}
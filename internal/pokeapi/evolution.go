@@ -0,0 +1,164 @@
+package pokeapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// When calling https://pokeapi.co/api/v2/evolution-chain/{id}/
+type EvolutionChain struct {
+	ID              int               `json:"id"`
+	BabyTriggerItem *NamedAPIResource `json:"baby_trigger_item"`
+	Chain           ChainLink         `json:"chain"`
+}
+
+// ChainLink is a species in an evolution chain along with the species
+// it can evolve into
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedAPIResource  `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail lists the conditions of one way to evolve into a
+// species. Nil or zero fields are not required.
+type EvolutionDetail struct {
+	Trigger               NamedAPIResource  `json:"trigger"`
+	Item                  *NamedAPIResource `json:"item"`
+	Gender                *int              `json:"gender"`
+	HeldItem              *NamedAPIResource `json:"held_item"`
+	KnownMove             *NamedAPIResource `json:"known_move"`
+	KnownMoveType         *NamedAPIResource `json:"known_move_type"`
+	Location              *NamedAPIResource `json:"location"`
+	MinLevel              *int              `json:"min_level"`
+	MinHappiness          *int              `json:"min_happiness"`
+	MinBeauty             *int              `json:"min_beauty"`
+	MinAffection          *int              `json:"min_affection"`
+	NeedsOverworldRain    bool              `json:"needs_overworld_rain"`
+	PartySpecies          *NamedAPIResource `json:"party_species"`
+	PartyType             *NamedAPIResource `json:"party_type"`
+	RelativePhysicalStats *int              `json:"relative_physical_stats"`
+	TimeOfDay             string            `json:"time_of_day"`
+	TradeSpecies          *NamedAPIResource `json:"trade_species"`
+	TurnUpsideDown        bool              `json:"turn_upside_down"`
+}
+
+// FetchEvolutionChain fetches an evolution chain by id
+func (c *Client) FetchEvolutionChain(id int) (EvolutionChain, error) {
+	url := fmt.Sprintf("%s/evolution-chain/%d", c.baseURL, id)
+	chain, err := getJSON[EvolutionChain](c, url, staticTTL)
+	if err != nil {
+		return EvolutionChain{}, fmt.Errorf("failed to fetch evolution chain: %w", err)
+	}
+	return chain, nil
+}
+
+// FetchSpeciesEvolutionChain fetches the evolution chain a species
+// belongs to
+func (c *Client) FetchSpeciesEvolutionChain(species string) (EvolutionChain, error) {
+	details, err := c.FetchPokemonSpecies(species)
+	if err != nil {
+		return EvolutionChain{}, err
+	}
+
+	id, err := ResourceID(details.EvolutionChain.URL)
+	if err != nil {
+		return EvolutionChain{}, fmt.Errorf("invalid evolution chain of %s: %w", species, err)
+	}
+	return c.FetchEvolutionChain(id)
+}
+
+// Find returns the link of the given species in the chain
+func (l *ChainLink) Find(species string) (*ChainLink, bool) {
+	if l.Species.Name == species {
+		return l, true
+	}
+	for i := range l.EvolvesTo {
+		if found, ok := l.EvolvesTo[i].Find(species); ok {
+			return found, true
+		}
+	}
+	return nil, false
+}
+
+// Describe returns the conditions of the evolution in plain words,
+// e.g. "level 16" or "use water-stone"
+func (d EvolutionDetail) Describe() string {
+	conditions := []string{}
+
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil {
+			conditions = append(conditions, fmt.Sprintf("level %d", *d.MinLevel))
+		} else {
+			conditions = append(conditions, "level up")
+		}
+	case "use-item":
+		if d.Item != nil {
+			conditions = append(conditions, "use "+d.Item.Name)
+		}
+	case "trade":
+		conditions = append(conditions, "trade")
+	default:
+		conditions = append(conditions, d.Trigger.Name)
+	}
+
+	if d.MinHappiness != nil {
+		conditions = append(conditions, fmt.Sprintf("friendship %d+", *d.MinHappiness))
+	}
+	if d.MinAffection != nil {
+		conditions = append(conditions, fmt.Sprintf("affection %d+", *d.MinAffection))
+	}
+	if d.MinBeauty != nil {
+		conditions = append(conditions, fmt.Sprintf("beauty %d+", *d.MinBeauty))
+	}
+	if d.TimeOfDay != "" {
+		conditions = append(conditions, "during the "+d.TimeOfDay)
+	}
+	if d.HeldItem != nil {
+		conditions = append(conditions, "holding "+d.HeldItem.Name)
+	}
+	if d.KnownMove != nil {
+		conditions = append(conditions, "knowing "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil {
+		conditions = append(conditions, "knowing a "+d.KnownMoveType.Name+" move")
+	}
+	if d.Location != nil {
+		conditions = append(conditions, "at "+d.Location.Name)
+	}
+	if d.Gender != nil {
+		conditions = append(conditions, GenderName(*d.Gender)+" only")
+	}
+	if d.TradeSpecies != nil {
+		conditions = append(conditions, "for "+d.TradeSpecies.Name)
+	}
+	if d.PartySpecies != nil {
+		conditions = append(conditions, "with "+d.PartySpecies.Name+" in the party")
+	}
+	if d.PartyType != nil {
+		conditions = append(conditions, "with a "+d.PartyType.Name+" pokemon in the party")
+	}
+	if d.RelativePhysicalStats != nil {
+		conditions = append(conditions, map[int]string{
+			1:  "attack > defense",
+			0:  "attack = defense",
+			-1: "attack < defense",
+		}[*d.RelativePhysicalStats])
+	}
+	if d.NeedsOverworldRain {
+		conditions = append(conditions, "while raining")
+	}
+	if d.TurnUpsideDown {
+		conditions = append(conditions, "holding the console upside down")
+	}
+
+	return strings.Join(conditions, ", ")
+}
+
+// GenderName returns the name of a gender id such as the one of an
+// evolution detail, matching the genders of owned Pokémon
+func GenderName(id int) string {
+	return map[int]string{1: "female", 2: "male", 3: "genderless"}[id]
+}
@@ -1,11 +1,5 @@
 package pokeapi
 
-import (
-	"encoding/json"
-	"io"
-	"net/http"
-)
-
 type RespLocationsDetail struct {
 	ID                   int    `json:"id"`
 	Name                 string `json:"name"`
@@ -39,63 +33,12 @@ type RespLocationsDetail struct {
 			Name string `json:"name"`
 			URL  string `json:"url"`
 		} `json:"pokemon"`
-		VersionDetails []struct {
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-			MaxChance        int `json:"max_chance"`
-			EncounterDetails []struct {
-				MinLevel        int   `json:"min_level"`
-				MaxLevel        int   `json:"max_level"`
-				ConditionValues []any `json:"condition_values"`
-				Chance          int   `json:"chance"`
-				Method          struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-			} `json:"encounter_details"`
-		} `json:"version_details"`
+		VersionDetails []VersionEncounterDetail `json:"version_details"`
 	} `json:"pokemon_encounters"`
 }
 
-// ListLocations -
+// ListExplore fetches the details of a single location area
 func (c *Client) ListExplore(area string) (RespLocationsDetail, error) {
 	url := c.baseURL + "/location-area" + "/" + area
-
-	// Check cache first
-	if cachedData, found := c.cache.Get(url); found {
-		var locationsDetailResp RespLocationsDetail
-		err := json.Unmarshal(cachedData, &locationsDetailResp)
-		if err == nil {
-			return locationsDetailResp, nil
-		}
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return RespLocationsDetail{}, err
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return RespLocationsDetail{}, err
-	}
-	defer resp.Body.Close()
-
-	dat, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return RespLocationsDetail{}, err
-	}
-
-	locationsDetailResp := RespLocationsDetail{}
-	err = json.Unmarshal(dat, &locationsDetailResp)
-	if err != nil {
-		return RespLocationsDetail{}, err
-	}
-
-	// Store response in cache
-	c.cache.Add(url, dat)
-
-	return locationsDetailResp, nil
+	return getJSON[RespLocationsDetail](c, url, staticTTL)
 }
@@ -0,0 +1,49 @@
+package pokeapi
+
+import "fmt"
+
+// When calling https://pokeapi.co/api/v2/growth-rate/{name}/
+// Levels lists the total experience needed to reach each level.
+type GrowthRate struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+	Levels  []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+	PokemonSpecies []NamedAPIResource `json:"pokemon_species"`
+}
+
+// FetchGrowthRate fetches how much experience a growth rate needs per level
+func (c *Client) FetchGrowthRate(name string) (GrowthRate, error) {
+	url := fmt.Sprintf("%s/growth-rate/%s", c.baseURL, name)
+	rate, err := getJSON[GrowthRate](c, url, staticTTL)
+	if err != nil {
+		return GrowthRate{}, fmt.Errorf("failed to fetch growth rate: %w", err)
+	}
+	return rate, nil
+}
+
+// Experience returns the total experience needed to reach a level, or
+// -1 when the level isn't listed
+func (g GrowthRate) Experience(level int) int {
+	for _, l := range g.Levels {
+		if l.Level == level {
+			return l.Experience
+		}
+	}
+	return -1
+}
+
+// Level returns the highest level reached with the given total
+// experience
+func (g GrowthRate) Level(experience int) int {
+	level := 1
+	for _, l := range g.Levels {
+		if l.Experience <= experience && l.Level > level {
+			level = l.Level
+		}
+	}
+	return level
+}
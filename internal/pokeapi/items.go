@@ -0,0 +1,65 @@
+package pokeapi
+
+import "fmt"
+
+// When calling https://pokeapi.co/api/v2/item/{name}/
+// FlingPower is nil for items that can't be flung.
+type Item struct {
+	ID            int                `json:"id"`
+	Name          string             `json:"name"`
+	Cost          int                `json:"cost"`
+	FlingPower    *int               `json:"fling_power"`
+	FlingEffect   *NamedAPIResource  `json:"fling_effect"`
+	Attributes    []NamedAPIResource `json:"attributes"`
+	Category      NamedAPIResource   `json:"category"`
+	EffectEntries []struct {
+		Effect      string           `json:"effect"`
+		ShortEffect string           `json:"short_effect"`
+		Language    NamedAPIResource `json:"language"`
+	} `json:"effect_entries"`
+	HeldByPokemon []struct {
+		Pokemon        NamedAPIResource `json:"pokemon"`
+		VersionDetails []struct {
+			Rarity  int              `json:"rarity"`
+			Version NamedAPIResource `json:"version"`
+		} `json:"version_details"`
+	} `json:"held_by_pokemon"`
+}
+
+// When calling https://pokeapi.co/api/v2/item-category/{name}/
+type ItemCategory struct {
+	ID     int                `json:"id"`
+	Name   string             `json:"name"`
+	Items  []NamedAPIResource `json:"items"`
+	Pocket NamedAPIResource   `json:"pocket"`
+}
+
+// FetchItem fetches the details of an item
+func (c *Client) FetchItem(name string) (Item, error) {
+	url := fmt.Sprintf("%s/item/%s", c.baseURL, name)
+	item, err := getJSON[Item](c, url, staticTTL)
+	if err != nil {
+		return Item{}, fmt.Errorf("failed to fetch item: %w", err)
+	}
+	return item, nil
+}
+
+// FetchItemCategory fetches an item category and the items in it
+func (c *Client) FetchItemCategory(name string) (ItemCategory, error) {
+	url := fmt.Sprintf("%s/item-category/%s", c.baseURL, name)
+	category, err := getJSON[ItemCategory](c, url, staticTTL)
+	if err != nil {
+		return ItemCategory{}, fmt.Errorf("failed to fetch item category: %w", err)
+	}
+	return category, nil
+}
+
+// Effect returns the effect and short effect texts in the given language
+func (i Item) Effect(language string) (effect string, short string, ok bool) {
+	for _, entry := range i.EffectEntries {
+		if entry.Language.Name == language {
+			return entry.Effect, entry.ShortEffect, true
+		}
+	}
+	return "", "", false
+}
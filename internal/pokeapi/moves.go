@@ -0,0 +1,68 @@
+package pokeapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// When calling https://pokeapi.co/api/v2/move/{name}/
+// Power, Accuracy and PP are nil for moves without them, e.g. status
+// moves have no power and moves that never miss have no accuracy.
+type Move struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Accuracy      *int             `json:"accuracy"`
+	EffectChance  *int             `json:"effect_chance"`
+	PP            *int             `json:"pp"`
+	Priority      int              `json:"priority"`
+	Power         *int             `json:"power"`
+	Type          NamedAPIResource `json:"type"`
+	DamageClass   NamedAPIResource `json:"damage_class"`
+	Target        NamedAPIResource `json:"target"`
+	Generation    NamedAPIResource `json:"generation"`
+	EffectEntries []struct {
+		Effect      string           `json:"effect"`
+		ShortEffect string           `json:"short_effect"`
+		Language    NamedAPIResource `json:"language"`
+	} `json:"effect_entries"`
+	Meta *struct {
+		Ailment       NamedAPIResource `json:"ailment"`
+		Category      NamedAPIResource `json:"category"`
+		MinHits       *int             `json:"min_hits"`
+		MaxHits       *int             `json:"max_hits"`
+		Drain         int              `json:"drain"`
+		Healing       int              `json:"healing"`
+		CritRate      int              `json:"crit_rate"`
+		AilmentChance int              `json:"ailment_chance"`
+		FlinchChance  int              `json:"flinch_chance"`
+		StatChance    int              `json:"stat_chance"`
+	} `json:"meta"`
+	LearnedByPokemon []NamedAPIResource `json:"learned_by_pokemon"`
+}
+
+// FetchMove fetches the details of a move
+func (c *Client) FetchMove(name string) (Move, error) {
+	url := fmt.Sprintf("%s/move/%s", c.baseURL, name)
+	move, err := getJSON[Move](c, url, staticTTL)
+	if err != nil {
+		return Move{}, fmt.Errorf("failed to fetch move: %w", err)
+	}
+	return move, nil
+}
+
+// ShortEffect returns the short effect text in the given language with
+// the effect chance filled in
+func (m Move) ShortEffect(language string) string {
+	for _, entry := range m.EffectEntries {
+		if entry.Language.Name != language {
+			continue
+		}
+		chance := ""
+		if m.EffectChance != nil {
+			chance = strconv.Itoa(*m.EffectChance)
+		}
+		return strings.ReplaceAll(entry.ShortEffect, "$effect_chance", chance)
+	}
+	return ""
+}
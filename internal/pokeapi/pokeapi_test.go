@@ -2,11 +2,14 @@ package pokeapi
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi/pokeapitest"
 	"github.com/nurusanwe/pokedexcli/internal/pokecache"
 )
 
@@ -116,23 +119,7 @@ func TestListExplore(t *testing.T) {
 				Name string `json:"name"`
 				URL  string `json:"url"`
 			} `json:"pokemon"`
-			VersionDetails []struct {
-				Version struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"version"`
-				MaxChance        int `json:"max_chance"`
-				EncounterDetails []struct {
-					MinLevel        int   `json:"min_level"`
-					MaxLevel        int   `json:"max_level"`
-					ConditionValues []any `json:"condition_values"`
-					Chance          int   `json:"chance"`
-					Method          struct {
-						Name string `json:"name"`
-						URL  string `json:"url"`
-					} `json:"method"`
-				} `json:"encounter_details"`
-			} `json:"version_details"`
+			VersionDetails []VersionEncounterDetail `json:"version_details"`
 		}{
 			{
 				Pokemon: struct {
@@ -142,51 +129,20 @@ func TestListExplore(t *testing.T) {
 					Name: "pikachu",
 					URL:  "https://pokeapi.co/api/v2/pokemon/25/",
 				},
-				VersionDetails: []struct {
-					Version struct {
-						Name string `json:"name"`
-						URL  string `json:"url"`
-					} `json:"version"`
-					MaxChance        int `json:"max_chance"`
-					EncounterDetails []struct {
-						MinLevel        int   `json:"min_level"`
-						MaxLevel        int   `json:"max_level"`
-						ConditionValues []any `json:"condition_values"`
-						Chance          int   `json:"chance"`
-						Method          struct {
-							Name string `json:"name"`
-							URL  string `json:"url"`
-						} `json:"method"`
-					} `json:"encounter_details"`
-				}{
+				VersionDetails: []VersionEncounterDetail{
 					{
-						Version: struct {
-							Name string `json:"name"`
-							URL  string `json:"url"`
-						}{
+						Version: NamedAPIResource{
 							Name: "red",
 							URL:  "https://pokeapi.co/api/v2/version/1/",
 						},
 						MaxChance: 100,
-						EncounterDetails: []struct {
-							MinLevel        int   `json:"min_level"`
-							MaxLevel        int   `json:"max_level"`
-							ConditionValues []any `json:"condition_values"`
-							Chance          int   `json:"chance"`
-							Method          struct {
-								Name string `json:"name"`
-								URL  string `json:"url"`
-							} `json:"method"`
-						}{
+						EncounterDetails: []Encounter{
 							{
 								MinLevel:        5,
 								MaxLevel:        10,
-								ConditionValues: []any{},
+								ConditionValues: []NamedAPIResource{},
 								Chance:          50,
-								Method: struct {
-									Name string `json:"name"`
-									URL  string `json:"url"`
-								}{
+								Method: NamedAPIResource{
 									Name: "walk",
 									URL:  "https://pokeapi.co/api/v2/encounter-method/1/",
 								},
@@ -289,3 +245,419 @@ func TestFetchPokemonDetails(t *testing.T) {
 		t.Fatalf("expected Weight %d, got %d", mockResponse.Weight, resp.Weight)
 	}
 }
+
+func TestFetchPokemonDetails_DecodedCache(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PokemonDetails{ID: 1, Name: "bulbasaur"})
+	}))
+	defer ts.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second)
+	client.baseURL = ts.URL
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.FetchPokemonDetails("bulbasaur")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if resp.Name != "bulbasaur" {
+			t.Fatalf("expected bulbasaur, got %s", resp.Name)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+	if _, found := client.objects.Get(ts.URL + "/pokemon/bulbasaur"); !found {
+		t.Errorf("expected decoded pokemon in objects cache")
+	}
+}
+
+func TestPrefetch(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/location-area" && r.URL.Query().Get("offset") == "":
+			next := ts.URL + "/location-area?offset=1&limit=1"
+			fmt.Fprintf(w, `{"count":2,"next":%q,"previous":null,"results":[{"name":"area-a"}]}`, next)
+		case r.URL.Path == "/location-area":
+			fmt.Fprint(w, `{"count":2,"next":null,"previous":null,"results":[{"name":"area-b"}]}`)
+		case r.URL.Path == "/location-area/area-a":
+			fmt.Fprint(w, `{"name":"area-a","pokemon_encounters":[{"pokemon":{"name":"pikachu"}},{"pokemon":{"name":"zubat"}}]}`)
+		case r.URL.Path == "/location-area/area-b":
+			fmt.Fprint(w, `{"name":"area-b","pokemon_encounters":[{"pokemon":{"name":"zubat"}}]}`)
+		case r.URL.Path == "/pokemon/pikachu":
+			fmt.Fprint(w, `{"id":25,"name":"pikachu","species":{"name":"pikachu"}}`)
+		case r.URL.Path == "/pokemon-species/pikachu":
+			fmt.Fprint(w, `{"id":25,"name":"pikachu","growth_rate":{"name":"medium-fast"}}`)
+		case r.URL.Path == "/growth-rate/medium-fast":
+			fmt.Fprint(w, `{"id":2,"name":"medium-fast","levels":[{"level":5,"experience":125}]}`)
+		case r.URL.Path == "/type" && r.URL.Query().Get("limit") != "":
+			fmt.Fprint(w, `{"count":1,"next":null,"previous":null,"results":[{"name":"electric"}]}`)
+		case r.URL.Path == "/type/electric":
+			fmt.Fprint(w, `{"id":13,"name":"electric","damage_relations":{"double_damage_to":[{"name":"water"}]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second)
+	client.baseURL = ts.URL
+
+	opts := DefaultPrefetchOptions()
+	opts.RequestsPerSecond = 0
+	stats, err := client.Prefetch(opts)
+	if err == nil {
+		t.Fatal("expected an error for the missing pokemon")
+	}
+
+	if stats.Pages != 2 || stats.Areas != 2 || stats.Pokemon != 1 || stats.Species != 1 ||
+		stats.GrowthRates != 1 || stats.Types != 1 || stats.Failed != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// Everything fetched must now be served without the server
+	ts.Close()
+	if _, err := client.ListExplore("area-b"); err != nil {
+		t.Errorf("expected cached area, got %v", err)
+	}
+	if _, err := client.FetchPokemonDetails("pikachu"); err != nil {
+		t.Errorf("expected cached pokemon, got %v", err)
+	}
+	if _, err := client.FetchGrowthRate("medium-fast"); err != nil {
+		t.Errorf("expected cached growth rate, got %v", err)
+	}
+	if types, err := client.FetchAllTypes(); err != nil || len(types) != 1 {
+		t.Errorf("expected cached types, got %v, %v", types, err)
+	}
+}
+
+func TestFetchPokemonSpecies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon-species/pikachu" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{
+			"id": 25, "name": "pikachu", "capture_rate": 190, "base_happiness": 50,
+			"growth_rate": {"name": "medium-fast"},
+			"egg_groups": [{"name": "ground"}, {"name": "fairy"}],
+			"habitat": {"name": "forest"}, "generation": {"name": "generation-i"},
+			"is_legendary": false, "is_mythical": false,
+			"genera": [{"genus": "Mouse Pokémon", "language": {"name": "en"}}],
+			"flavor_text_entries": [
+				{"flavor_text": "When several of\nthese POKéMON\ngather, their\felectricity could\nbuild and cause\nlightning storms.", "language": {"name": "en"}, "version": {"name": "red"}},
+				{"flavor_text": "Quand plusieurs de ces POKéMON se réunissent...", "language": {"name": "fr"}, "version": {"name": "x"}},
+				{"flavor_text": "It lives in forests with others.", "language": {"name": "en"}, "version": {"name": "diamond"}}
+			]
+		}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second, WithBaseURL(ts.URL))
+
+	species, err := client.FetchPokemonSpecies("pikachu")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if species.CaptureRate != 190 || species.GrowthRate.Name != "medium-fast" || len(species.EggGroups) != 2 {
+		t.Errorf("unexpected species %+v", species)
+	}
+	if species.Habitat == nil || species.Habitat.Name != "forest" {
+		t.Errorf("expected forest habitat, got %v", species.Habitat)
+	}
+	if genus := species.Genus("en"); genus != "Mouse Pokémon" {
+		t.Errorf("expected Mouse Pokémon, got %q", genus)
+	}
+
+	text, version, found := species.FlavorText("red", "en")
+	if !found || version != "red" {
+		t.Fatalf("expected the red entry, got %q from %q", text, version)
+	}
+	if text != "When several of these POKéMON gather, their electricity could build and cause lightning storms." {
+		t.Errorf("expected line breaks to be cleaned up, got %q", text)
+	}
+	if _, version, _ := species.FlavorText("", "en"); version != "diamond" {
+		t.Errorf("expected the latest english entry, got %q", version)
+	}
+	if _, _, found := species.FlavorText("gold", "en"); found {
+		t.Errorf("expected no entry for gold")
+	}
+
+	if _, err := client.FetchPokemonSpecies("missingno"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown species, got %v", err)
+	}
+}
+
+func TestFetchSpeciesEvolutionChain(t *testing.T) {
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second, WithBaseURL(server.BaseURL()))
+	chain, err := client.FetchSpeciesEvolutionChain("ivysaur")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if chain.Chain.Species.Name != "bulbasaur" {
+		t.Errorf("expected the chain to start at bulbasaur, got %s", chain.Chain.Species.Name)
+	}
+
+	link, found := chain.Chain.Find("ivysaur")
+	if !found || len(link.EvolvesTo) != 1 || link.EvolvesTo[0].Species.Name != "venusaur" {
+		t.Fatalf("expected ivysaur to evolve into venusaur, got %+v", link)
+	}
+	if how := link.EvolvesTo[0].EvolutionDetails[0].Describe(); how != "level 32" {
+		t.Errorf("expected level 32, got %q", how)
+	}
+	if _, found := chain.Chain.Find("pikachu"); found {
+		t.Error("expected pikachu not to be in the bulbasaur chain")
+	}
+}
+
+func TestFetchMove(t *testing.T) {
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second, WithBaseURL(server.BaseURL()))
+	move, err := client.FetchMove("thunder-shock")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if move.Type.Name != "electric" || move.Power == nil || *move.Power != 40 {
+		t.Errorf("expected a 40 power electric move, got %+v", move)
+	}
+	if effect := move.ShortEffect("en"); effect != "Has a 10% chance to paralyze the target." {
+		t.Errorf("expected the effect chance to be filled in, got %q", effect)
+	}
+
+	growl, err := client.FetchMove("growl")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if growl.Power != nil {
+		t.Errorf("expected a status move to have no power, got %d", *growl.Power)
+	}
+}
+
+func TestFetchAbility(t *testing.T) {
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second, WithBaseURL(server.BaseURL()))
+	ability, err := client.FetchAbility("static")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, short, found := ability.Effect("de")
+	if !found || short != "Kann bei Berührung paralysieren." {
+		t.Errorf("expected the german short effect, got %q", short)
+	}
+	if _, _, found := ability.Effect("fr"); found {
+		t.Error("expected no french description")
+	}
+
+	hidden := map[string]bool{}
+	for _, p := range ability.Pokemon {
+		hidden[p.Pokemon.Name] = p.IsHidden
+	}
+	if len(hidden) != 2 || hidden["pikachu"] || hidden["raichu"] {
+		t.Errorf("expected pikachu and raichu to have static as a regular ability, got %v", hidden)
+	}
+}
+
+func TestFetchItemsAndBerries(t *testing.T) {
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second, WithBaseURL(server.BaseURL()))
+	item, err := client.FetchItem("oran-berry")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if item.Cost != 20 || item.FlingPower == nil || *item.FlingPower != 10 {
+		t.Errorf("expected cost 20 and fling power 10, got %+v", item)
+	}
+	if len(item.HeldByPokemon) != 2 {
+		t.Errorf("expected pikachu and bidoof to hold it, got %+v", item.HeldByPokemon)
+	}
+
+	category, err := client.FetchItemCategory(item.Category.Name)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if category.Pocket.Name != "berries" {
+		t.Errorf("expected the berries pocket, got %s", category.Pocket.Name)
+	}
+
+	berries, err := client.FetchAllBerries()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(berries) != 10 || berries[0].Name != "cheri" || berries[9].Name != "sitrus" {
+		t.Errorf("expected 10 berries ordered by id, got %d", len(berries))
+	}
+
+	flavor, err := client.FetchBerryFlavor("spicy")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if flavor.ContestType.Name != "cool" || len(flavor.Berries) != 5 {
+		t.Errorf("expected 5 cool spicy berries, got %+v", flavor)
+	}
+}
+
+func TestFetchRegionLocations(t *testing.T) {
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second, WithBaseURL(server.BaseURL()))
+	region, err := client.FetchRegion("sinnoh")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	locations, err := client.FetchRegionLocations(region)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(locations) != len(region.Locations) {
+		t.Fatalf("expected %d locations, got %d", len(region.Locations), len(locations))
+	}
+	for i, loc := range locations {
+		if loc.Name != region.Locations[i].Name {
+			t.Errorf("expected location %d to be %s, got %s", i, region.Locations[i].Name, loc.Name)
+		}
+		if loc.Region == nil || loc.Region.Name != "sinnoh" {
+			t.Errorf("expected %s to be in sinnoh, got %+v", loc.Name, loc.Region)
+		}
+	}
+}
+
+func TestFetchLocationAreaEncounters(t *testing.T) {
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second, WithBaseURL(server.BaseURL()))
+	pokemon, err := client.FetchPokemonDetails("pikachu")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	encounters, err := client.FetchLocationAreaEncounters(pokemon.LocationAreaEncounters)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(encounters) != 3 || encounters[0].LocationArea.Name != "valley-windworks-area" {
+		t.Fatalf("expected 3 areas starting with valley-windworks-area, got %+v", encounters)
+	}
+	detail := encounters[0].VersionDetails[0].EncounterDetails[0]
+	if detail.Method.Name != "walk" || detail.MinLevel != 15 || detail.MaxLevel != 17 || detail.ConditionValues[0].Name != "radar-on" {
+		t.Errorf("unexpected encounter %+v", detail)
+	}
+
+	if _, err := client.FetchLocationAreaEncounters(""); err == nil {
+		t.Error("expected an error without a url")
+	}
+}
+
+func TestFetchGrowthRate(t *testing.T) {
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second, WithBaseURL(server.BaseURL()))
+	rate, err := client.FetchGrowthRate("medium-slow")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	cases := []struct {
+		level, experience int
+	}{
+		{1, 0},
+		{2, 9},
+		{5, 135},
+		{100, 1059860},
+	}
+	for _, c := range cases {
+		if got := rate.Experience(c.level); got != c.experience {
+			t.Errorf("expected %d experience at level %d, got %d", c.experience, c.level, got)
+		}
+		if got := rate.Level(c.experience); got != c.level {
+			t.Errorf("expected level %d with %d experience, got %d", c.level, c.experience, got)
+		}
+	}
+	if got := rate.Level(134); got != 4 {
+		t.Errorf("expected level 4 just short of level 5, got %d", got)
+	}
+	if got := rate.Experience(101); got != -1 {
+		t.Errorf("expected no experience past level 100, got %d", got)
+	}
+}
+
+func TestFetchPokedexAndGenerations(t *testing.T) {
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	client := NewClient(2*time.Second, 10*time.Second, WithBaseURL(server.BaseURL()))
+	dex, err := client.FetchPokedex("original-sinnoh")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dex.Region == nil || dex.Region.Name != "sinnoh" || len(dex.PokemonEntries) == 0 {
+		t.Fatalf("expected the sinnoh dex, got %+v", dex)
+	}
+	if first := dex.PokemonEntries[0]; first.EntryNumber != 10 || first.PokemonSpecies.Name != "starly" {
+		t.Errorf("expected starly first, got %+v", first)
+	}
+
+	national, err := client.FetchPokedex("national")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if national.Region != nil {
+		t.Errorf("expected the national dex to have no region, got %v", national.Region)
+	}
+
+	generations, err := client.FetchAllGenerations()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	names := []string{}
+	for _, g := range generations {
+		names = append(names, g.Name)
+	}
+	want := []string{"generation-i", "generation-ii", "generation-iii", "generation-iv"}
+	if fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+	if generations[3].MainRegion.Name != "sinnoh" || len(generations[3].PokemonSpecies) != 5 {
+		t.Errorf("expected the 5 sinnoh species in generation iv, got %+v", generations[3])
+	}
+}
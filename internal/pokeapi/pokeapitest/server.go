@@ -0,0 +1,260 @@
+// Package pokeapitest serves a small embedded PokéAPI dataset over HTTP
+// so the CLI and its tests can run against localhost.
+//
+// Resources live in data/<resource>/<name>.json. Sub-resources such as
+// /pokemon/{name}/encounters live next to them in
+// data/<resource>/<name>.<sub>.json. Every https://pokeapi.co/api/v2
+// URL in the payloads is rewritten to point at the fake server.
+package pokeapitest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// upstreamBaseURL is the prefix rewritten in the embedded payloads
+const upstreamBaseURL = "https://pokeapi.co/api/v2"
+
+// apiPrefix is the path the API is served under, like the real one
+const apiPrefix = "/api/v2"
+
+// defaultLimit is the page size of list endpoints, as upstream
+const defaultLimit = 20
+
+//go:embed data
+var dataFS embed.FS
+
+// Options tweak how the fake API behaves.
+// Latency delays every response.
+// ErrorRate is the probability (0-1) of answering with a 500.
+// Seed makes error injection reproducible.
+type Options struct {
+	Latency   time.Duration
+	ErrorRate float64
+	Seed      int64
+}
+
+// resource is a single entry of the dataset
+type resource struct {
+	id   int
+	name string
+}
+
+// Handler serves the embedded dataset
+type Handler struct {
+	opts      Options
+	mu        sync.Mutex
+	rng       *rand.Rand
+	resources map[string][]resource
+}
+
+// NewHandler indexes the embedded dataset and returns its handler
+func NewHandler(opts Options) (*Handler, error) {
+	h := &Handler{
+		opts:      opts,
+		rng:       rand.New(rand.NewSource(opts.Seed)),
+		resources: map[string][]resource{},
+	}
+
+	dirs, err := fs.ReadDir(dataFS, "data")
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		files, err := fs.ReadDir(dataFS, path.Join("data", dir.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			name := strings.TrimSuffix(file.Name(), ".json")
+			if strings.Contains(name, ".") {
+				// Sub-resources aren't part of the list
+				continue
+			}
+
+			dat, err := dataFS.ReadFile(path.Join("data", dir.Name(), file.Name()))
+			if err != nil {
+				return nil, err
+			}
+			var header struct {
+				ID int `json:"id"`
+			}
+			if err := json.Unmarshal(dat, &header); err != nil {
+				return nil, fmt.Errorf("invalid %s/%s: %w", dir.Name(), file.Name(), err)
+			}
+			h.resources[dir.Name()] = append(h.resources[dir.Name()], resource{id: header.ID, name: name})
+		}
+		sort.Slice(h.resources[dir.Name()], func(i, j int) bool {
+			return h.resources[dir.Name()][i].id < h.resources[dir.Name()][j].id
+		})
+	}
+
+	return h, nil
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.opts.Latency > 0 {
+		time.Sleep(h.opts.Latency)
+	}
+	if h.injectError() {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rest, ok := strings.CutPrefix(r.URL.Path, apiPrefix)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	baseURL := "http://" + r.Host + apiPrefix
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+	switch {
+	case len(segments) == 1 && segments[0] != "":
+		h.serveList(w, r, baseURL, segments[0])
+	case len(segments) >= 2:
+		h.serveResource(w, r, baseURL, segments[0], segments[1], segments[2:])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveList answers /{resource}?offset=&limit= with pagination links
+func (h *Handler) serveList(w http.ResponseWriter, r *http.Request, baseURL, kind string) {
+	all, found := h.resources[kind]
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	offset := queryInt(r, "offset", 0)
+	limit := queryInt(r, "limit", defaultLimit)
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if offset > len(all) {
+		offset = len(all)
+	}
+	end := min(offset+limit, len(all))
+
+	type namedResource struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+	page := struct {
+		Count    int             `json:"count"`
+		Next     *string         `json:"next"`
+		Previous *string         `json:"previous"`
+		Results  []namedResource `json:"results"`
+	}{
+		Count:   len(all),
+		Results: []namedResource{},
+	}
+	for _, res := range all[offset:end] {
+		page.Results = append(page.Results, namedResource{
+			Name: res.name,
+			URL:  fmt.Sprintf("%s/%s/%d/", baseURL, kind, res.id),
+		})
+	}
+	if end < len(all) {
+		next := fmt.Sprintf("%s/%s?offset=%d&limit=%d", baseURL, kind, end, limit)
+		page.Next = &next
+	}
+	if offset > 0 {
+		prev := fmt.Sprintf("%s/%s?offset=%d&limit=%d", baseURL, kind, max(offset-limit, 0), limit)
+		page.Previous = &prev
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(page)
+}
+
+// serveResource answers /{resource}/{name or id}[/{sub}]
+func (h *Handler) serveResource(w http.ResponseWriter, r *http.Request, baseURL, kind, key string, sub []string) {
+	name, found := h.lookup(kind, key)
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	file := name
+	if len(sub) > 0 && sub[0] != "" {
+		file += "." + strings.Join(sub, ".")
+	}
+	dat, err := dataFS.ReadFile(path.Join("data", kind, file+".json"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	dat = []byte(strings.ReplaceAll(string(dat), upstreamBaseURL, baseURL))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(dat)
+}
+
+// lookup resolves a name or numeric id to a dataset name
+func (h *Handler) lookup(kind, key string) (string, bool) {
+	id, err := strconv.Atoi(key)
+	for _, res := range h.resources[kind] {
+		if res.name == key || (err == nil && res.id == id) {
+			return res.name, true
+		}
+	}
+	return "", false
+}
+
+// injectError decides whether the current request should fail
+func (h *Handler) injectError() bool {
+	if h.opts.ErrorRate <= 0 {
+		return false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.rng.Float64() < h.opts.ErrorRate
+}
+
+// queryInt reads a non-negative integer query parameter
+func queryInt(r *http.Request, key string, fallback int) int {
+	val, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil || val < 0 {
+		return fallback
+	}
+	return val
+}
+
+// Server is a running fake PokéAPI for tests
+type Server struct {
+	*httptest.Server
+}
+
+// NewServer starts a fake PokéAPI. Callers must Close it.
+func NewServer(opts Options) (*Server, error) {
+	h, err := NewHandler(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{Server: httptest.NewServer(h)}, nil
+}
+
+// BaseURL returns the API root to hand to pokeapi.WithBaseURL
+func (s *Server) BaseURL() string {
+	return s.URL + apiPrefix
+}
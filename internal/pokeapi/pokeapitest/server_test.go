@@ -0,0 +1,119 @@
+package pokeapitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type page struct {
+	Count    int     `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+func getJSON(t *testing.T, url string, out any) int {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK && out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("failed to decode %s: %v", url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestPagination(t *testing.T) {
+	server, err := NewServer(Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	var first page
+	getJSON(t, server.BaseURL()+"/location-area", &first)
+	if len(first.Results) != defaultLimit {
+		t.Fatalf("expected %d results, got %d", defaultLimit, len(first.Results))
+	}
+	if first.Previous != nil {
+		t.Errorf("expected no previous link on the first page")
+	}
+	if first.Next == nil || !strings.HasPrefix(*first.Next, server.BaseURL()) {
+		t.Fatalf("expected a next link on the fake server, got %v", first.Next)
+	}
+
+	seen := len(first.Results)
+	url := *first.Next
+	for {
+		var next page
+		getJSON(t, url, &next)
+		if next.Previous == nil {
+			t.Errorf("expected a previous link on %s", url)
+		}
+		seen += len(next.Results)
+		if next.Next == nil {
+			break
+		}
+		url = *next.Next
+	}
+	if seen != first.Count {
+		t.Errorf("expected to page through %d areas, saw %d", first.Count, seen)
+	}
+}
+
+func TestResources(t *testing.T) {
+	server, err := NewServer(Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	var pokemon struct {
+		ID      int    `json:"id"`
+		Name    string `json:"name"`
+		Species struct {
+			URL string `json:"url"`
+		} `json:"species"`
+	}
+	for _, key := range []string{"pikachu", "25"} {
+		if status := getJSON(t, server.BaseURL()+"/pokemon/"+key, &pokemon); status != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d", key, status)
+		}
+		if pokemon.ID != 25 || pokemon.Name != "pikachu" {
+			t.Errorf("unexpected pokemon %+v", pokemon)
+		}
+	}
+	if !strings.HasPrefix(pokemon.Species.URL, server.BaseURL()) {
+		t.Errorf("expected URLs to point at the fake server, got %s", pokemon.Species.URL)
+	}
+
+	for _, path := range []string{"/pokemon/missingno", "/nothing", "/nothing/1", "/"} {
+		if status := getJSON(t, server.BaseURL()+path, nil); status != http.StatusNotFound {
+			t.Errorf("expected 404 for %s, got %d", path, status)
+		}
+	}
+}
+
+func TestErrorInjection(t *testing.T) {
+	server, err := NewServer(Options{ErrorRate: 1, Latency: time.Millisecond})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer server.Close()
+
+	if status := getJSON(t, server.BaseURL()+"/pokemon/pikachu", nil); status != http.StatusInternalServerError {
+		t.Errorf("expected injected 500, got %d", status)
+	}
+}
@@ -0,0 +1,74 @@
+package pokeapi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// generationWorkers bounds the concurrent requests of FetchAllGenerations
+const generationWorkers = 4
+
+// When calling https://pokeapi.co/api/v2/pokedex/{name}/
+// Region is nil for the national dex.
+type Pokedex struct {
+	ID             int               `json:"id"`
+	Name           string            `json:"name"`
+	IsMainSeries   bool              `json:"is_main_series"`
+	Region         *NamedAPIResource `json:"region"`
+	PokemonEntries []struct {
+		EntryNumber    int              `json:"entry_number"`
+		PokemonSpecies NamedAPIResource `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+// When calling https://pokeapi.co/api/v2/generation/{name}/
+type Generation struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	MainRegion     NamedAPIResource   `json:"main_region"`
+	PokemonSpecies []NamedAPIResource `json:"pokemon_species"`
+}
+
+// FetchPokedex fetches a national or regional Pokédex and its entries
+func (c *Client) FetchPokedex(name string) (Pokedex, error) {
+	url := fmt.Sprintf("%s/pokedex/%s", c.baseURL, name)
+	dex, err := getJSON[Pokedex](c, url, staticTTL)
+	if err != nil {
+		return Pokedex{}, fmt.Errorf("failed to fetch pokedex: %w", err)
+	}
+	return dex, nil
+}
+
+// FetchGeneration fetches a generation and the species it introduced
+func (c *Client) FetchGeneration(name string) (Generation, error) {
+	url := fmt.Sprintf("%s/generation/%s", c.baseURL, name)
+	generation, err := getJSON[Generation](c, url, staticTTL)
+	if err != nil {
+		return Generation{}, fmt.Errorf("failed to fetch generation: %w", err)
+	}
+	return generation, nil
+}
+
+// FetchAllGenerations fetches every generation, ordered by id
+func (c *Client) FetchAllGenerations() ([]Generation, error) {
+	list, err := c.ListResources("generation")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(list))
+	for _, g := range list {
+		names = append(names, g.Name)
+	}
+	fetched, err := FetchConcurrently(names, generationWorkers, c.FetchGeneration)
+	if err != nil {
+		return nil, err
+	}
+
+	generations := make([]Generation, 0, len(fetched))
+	for _, g := range fetched {
+		generations = append(generations, g)
+	}
+	sort.Slice(generations, func(i, j int) bool { return generations[i].ID < generations[j].ID })
+	return generations, nil
+}
@@ -1,9 +1,7 @@
 package pokeapi
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 // struct
@@ -283,21 +281,9 @@ type PokemonDetails struct {
 // FetchPokemonDetails fetches details of a single Pokémon
 func (c *Client) FetchPokemonDetails(pokemon string) (PokemonDetails, error) {
 	url := fmt.Sprintf("%s/pokemon/%s", c.baseURL, pokemon)
-	resp, err := c.httpClient.Get(url)
+	details, err := getJSON[PokemonDetails](c, url, staticTTL)
 	if err != nil {
 		return PokemonDetails{}, fmt.Errorf("failed to fetch pokemon details: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return PokemonDetails{}, fmt.Errorf("failed to fetch pokemon details: status code %d", resp.StatusCode)
-	}
-
-	var details PokemonDetails
-	err = json.NewDecoder(resp.Body).Decode(&details)
-	if err != nil {
-		return PokemonDetails{}, fmt.Errorf("failed to parse pokemon details: %w", err)
-	}
-
 	return details, nil
 }
@@ -0,0 +1,174 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// PrefetchOptions tunes how the cache is warmed up.
+// Workers bounds the number of concurrent requests.
+// RequestsPerSecond caps the network request rate (0 disables it).
+// TTL is how long warmed entries are kept in the cache.
+// Progress, if set, is called after each fetched resource.
+type PrefetchOptions struct {
+	Workers           int
+	RequestsPerSecond int
+	TTL               time.Duration
+	Progress          func(stage string, done, total int)
+}
+
+// PrefetchStats summarises a prefetch run
+type PrefetchStats struct {
+	Pages       int
+	Areas       int
+	Pokemon     int
+	Species     int
+	GrowthRates int
+	Types       int
+	Failed      int
+}
+
+// DefaultPrefetchOptions returns options that stay polite with the PokéAPI
+func DefaultPrefetchOptions() PrefetchOptions {
+	return PrefetchOptions{
+		Workers:           8,
+		RequestsPerSecond: 20,
+		TTL:               30 * 24 * time.Hour,
+	}
+}
+
+// Prefetch walks every location area page, then fetches every area
+// detail and every Pokémon (with its species and growth rate) they
+// reference, and every type, so the cache can serve the CLI without
+// network access. Failed resources are counted and the joined error is
+// returned alongside the stats.
+func (c *Client) Prefetch(opts PrefetchOptions) (PrefetchStats, error) {
+	stats := PrefetchStats{}
+
+	wait := func() {}
+	if opts.RequestsPerSecond > 0 {
+		limiter := time.NewTicker(time.Second / time.Duration(opts.RequestsPerSecond))
+		defer limiter.Stop()
+		wait = func() { <-limiter.C }
+	}
+
+	// Pages have to be walked sequentially to follow the next links
+	areas := []string{}
+	url := c.baseURL + "/location-area"
+	for {
+		var page RespShallowLocations
+		if err := c.warm(url, opts.TTL, wait, &page); err != nil {
+			return stats, err
+		}
+		stats.Pages++
+		for _, loc := range page.Results {
+			areas = append(areas, loc.Name)
+		}
+		if opts.Progress != nil {
+			opts.Progress("pages", len(areas), page.Count)
+		}
+
+		if page.Next == nil {
+			break
+		}
+		url = *page.Next
+	}
+
+	details, areaErr := warmAll[RespLocationsDetail](c, opts, wait, "areas", "location-area", areas)
+	stats.Areas = len(details)
+
+	pokemon := []string{}
+	for _, detail := range details {
+		for _, enc := range detail.PokemonEncounters {
+			pokemon = append(pokemon, enc.Pokemon.Name)
+		}
+	}
+	pokemon = uniqueSorted(pokemon)
+	fetched, pokemonErr := warmAll[PokemonDetails](c, opts, wait, "pokemon", "pokemon", pokemon)
+	stats.Pokemon = len(fetched)
+
+	species := []string{}
+	for _, details := range fetched {
+		species = append(species, details.Species.Name)
+	}
+	species = uniqueSorted(species)
+	fetchedSpecies, speciesErr := warmAll[PokemonSpecies](c, opts, wait, "species", "pokemon-species", species)
+	stats.Species = len(fetchedSpecies)
+
+	// Catching and inspecting need the experience of each level
+	rates := []string{}
+	for _, details := range fetchedSpecies {
+		rates = append(rates, details.GrowthRate.Name)
+	}
+	rates = uniqueSorted(rates)
+	fetchedRates, rateErr := warmAll[GrowthRate](c, opts, wait, "growth rates", "growth-rate", rates)
+	stats.GrowthRates = len(fetchedRates)
+
+	// Battles and type charts need every type
+	types := []string{}
+	var list NamedAPIResourceList
+	listErr := c.warm(c.listURL("type"), opts.TTL, wait, &list)
+	if listErr != nil {
+		stats.Failed++
+	}
+	for _, t := range list.Results {
+		types = append(types, t.Name)
+	}
+	fetchedTypes, typeErr := warmAll[Type](c, opts, wait, "types", "type", types)
+	stats.Types = len(fetchedTypes)
+
+	stats.Failed += len(areas) - stats.Areas + len(pokemon) - stats.Pokemon + len(species) - stats.Species +
+		len(rates) - stats.GrowthRates + len(types) - stats.Types
+	return stats, errors.Join(areaErr, pokemonErr, speciesErr, rateErr, listErr, typeErr)
+}
+
+// warmAll warms the resource under path for each name, reporting the
+// progress of the stage
+func warmAll[T any](c *Client, opts PrefetchOptions, wait func(), stage, path string, names []string) (map[string]T, error) {
+	var done atomic.Int64
+	return FetchConcurrently(names, opts.Workers, func(name string) (T, error) {
+		var out T
+		err := c.warm(c.baseURL+"/"+path+"/"+name, opts.TTL, wait, &out)
+		if opts.Progress != nil {
+			opts.Progress(stage, int(done.Add(1)), len(names))
+		}
+		return out, err
+	})
+}
+
+// uniqueSorted returns the non-empty names once each, sorted
+func uniqueSorted(names []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, name := range names {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// warm makes sure url is in the raw cache for at least ttl and decodes
+// it into out. wait is only called before hitting the network.
+func (c *Client) warm(url string, ttl time.Duration, wait func(), out any) error {
+	dat, found := c.cache.Get(url)
+	if !found {
+		wait()
+		var err error
+		dat, err = c.fetch(url)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := json.Unmarshal(dat, out); err != nil {
+		return err
+	}
+	c.cache.AddWithTTL(url, dat, ttl)
+	return nil
+}
@@ -0,0 +1,63 @@
+package pokeapi
+
+import "fmt"
+
+// locationWorkers bounds the concurrent requests of FetchRegionLocations
+const locationWorkers = 8
+
+// When calling https://pokeapi.co/api/v2/region/{name}/
+type Region struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	Locations      []NamedAPIResource `json:"locations"`
+	MainGeneration NamedAPIResource   `json:"main_generation"`
+	Pokedexes      []NamedAPIResource `json:"pokedexes"`
+	VersionGroups  []NamedAPIResource `json:"version_groups"`
+}
+
+// When calling https://pokeapi.co/api/v2/location/{name}/
+type Location struct {
+	ID     int                `json:"id"`
+	Name   string             `json:"name"`
+	Region *NamedAPIResource  `json:"region"`
+	Areas  []NamedAPIResource `json:"areas"`
+}
+
+// FetchRegion fetches a region and the locations in it
+func (c *Client) FetchRegion(name string) (Region, error) {
+	url := fmt.Sprintf("%s/region/%s", c.baseURL, name)
+	region, err := getJSON[Region](c, url, staticTTL)
+	if err != nil {
+		return Region{}, fmt.Errorf("failed to fetch region: %w", err)
+	}
+	return region, nil
+}
+
+// FetchLocation fetches a location and the areas in it
+func (c *Client) FetchLocation(name string) (Location, error) {
+	url := fmt.Sprintf("%s/location/%s", c.baseURL, name)
+	location, err := getJSON[Location](c, url, staticTTL)
+	if err != nil {
+		return Location{}, fmt.Errorf("failed to fetch location: %w", err)
+	}
+	return location, nil
+}
+
+// FetchRegionLocations fetches a region's locations in the order the
+// region lists them
+func (c *Client) FetchRegionLocations(region Region) ([]Location, error) {
+	names := make([]string, 0, len(region.Locations))
+	for _, loc := range region.Locations {
+		names = append(names, loc.Name)
+	}
+	fetched, err := FetchConcurrently(names, locationWorkers, c.FetchLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]Location, 0, len(names))
+	for _, name := range names {
+		locations = append(locations, fetched[name])
+	}
+	return locations, nil
+}
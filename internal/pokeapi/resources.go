@@ -0,0 +1,54 @@
+package pokeapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NamedAPIResource is the {name, url} reference the PokéAPI uses to
+// link resources together.
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// APIResource is a reference to an unnamed resource such as an
+// evolution chain.
+type APIResource struct {
+	URL string `json:"url"`
+}
+
+// NamedAPIResourceList is a page of a resource list endpoint
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
+// listLimit is large enough to fetch any resource list in one request
+const listLimit = 100000
+
+// ListResources returns every entry of a resource list endpoint such
+// as /type or /move in a single request
+func (c *Client) ListResources(resource string) ([]NamedAPIResource, error) {
+	list, err := getJSON[NamedAPIResourceList](c, c.listURL(resource), staticTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", resource, err)
+	}
+	return list.Results, nil
+}
+
+// listURL is the URL of a whole resource list, shared with Prefetch so
+// they hit the same cache entry
+func (c *Client) listURL(resource string) string {
+	return fmt.Sprintf("%s/%s?offset=0&limit=%d", c.baseURL, resource, listLimit)
+}
+
+// ResourceID extracts the numeric id at the end of a resource URL such
+// as https://pokeapi.co/api/v2/evolution-chain/67/
+func ResourceID(url string) (int, error) {
+	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	return strconv.Atoi(parts[len(parts)-1])
+}
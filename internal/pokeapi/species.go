@@ -0,0 +1,95 @@
+package pokeapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// When calling https://pokeapi.co/api/v2/pokemon-species/{name}/
+type PokemonSpecies struct {
+	ID                 int                `json:"id"`
+	Name               string             `json:"name"`
+	Order              int                `json:"order"`
+	GenderRate         int                `json:"gender_rate"`
+	CaptureRate        int                `json:"capture_rate"`
+	BaseHappiness      int                `json:"base_happiness"`
+	IsBaby             bool               `json:"is_baby"`
+	IsLegendary        bool               `json:"is_legendary"`
+	IsMythical         bool               `json:"is_mythical"`
+	HatchCounter       int                `json:"hatch_counter"`
+	GrowthRate         NamedAPIResource   `json:"growth_rate"`
+	EggGroups          []NamedAPIResource `json:"egg_groups"`
+	Color              NamedAPIResource   `json:"color"`
+	Shape              *NamedAPIResource  `json:"shape"`
+	Habitat            *NamedAPIResource  `json:"habitat"`
+	Generation         NamedAPIResource   `json:"generation"`
+	EvolvesFromSpecies *NamedAPIResource  `json:"evolves_from_species"`
+	EvolutionChain     APIResource        `json:"evolution_chain"`
+	FlavorTextEntries  []struct {
+		FlavorText string           `json:"flavor_text"`
+		Language   NamedAPIResource `json:"language"`
+		Version    NamedAPIResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Genera []struct {
+		Genus    string           `json:"genus"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"genera"`
+	Names []struct {
+		Name     string           `json:"name"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"names"`
+	PokedexNumbers []struct {
+		EntryNumber int              `json:"entry_number"`
+		Pokedex     NamedAPIResource `json:"pokedex"`
+	} `json:"pokedex_numbers"`
+	Varieties []struct {
+		IsDefault bool             `json:"is_default"`
+		Pokemon   NamedAPIResource `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+// FetchPokemonSpecies fetches the species data shared by every form
+// of a Pokémon
+func (c *Client) FetchPokemonSpecies(species string) (PokemonSpecies, error) {
+	url := fmt.Sprintf("%s/pokemon-species/%s", c.baseURL, species)
+	details, err := getJSON[PokemonSpecies](c, url, staticTTL)
+	if err != nil {
+		return PokemonSpecies{}, fmt.Errorf("failed to fetch pokemon species: %w", err)
+	}
+	return details, nil
+}
+
+// FlavorText returns the Pokédex entry for the given game version and
+// language. An empty version picks the most recent entry available in
+// that language, whose version is returned alongside the text. The
+// game's hard line breaks are removed.
+func (s PokemonSpecies) FlavorText(version, language string) (string, string, bool) {
+	for i := len(s.FlavorTextEntries) - 1; i >= 0; i-- {
+		entry := s.FlavorTextEntries[i]
+		if entry.Language.Name != language {
+			continue
+		}
+		if version != "" && entry.Version.Name != version {
+			continue
+		}
+		return cleanFlavorText(entry.FlavorText), entry.Version.Name, true
+	}
+	return "", "", false
+}
+
+// Genus returns the species category, e.g. "Mouse Pokémon"
+func (s PokemonSpecies) Genus(language string) string {
+	for _, g := range s.Genera {
+		if g.Language.Name == language {
+			return g.Genus
+		}
+	}
+	return ""
+}
+
+// cleanFlavorText turns the game's form feeds, line breaks and soft
+// hyphens into plain spaced text
+func cleanFlavorText(text string) string {
+	replacer := strings.NewReplacer("\u00ad\n", "", "\u00ad", "", "\f", " ", "\n", " ")
+	return strings.Join(strings.Fields(replacer.Replace(text)), " ")
+}
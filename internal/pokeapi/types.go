@@ -0,0 +1,75 @@
+package pokeapi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// typeWorkers bounds the concurrent requests when fetching every type
+const typeWorkers = 6
+
+// When calling https://pokeapi.co/api/v2/type/{name}/
+type Type struct {
+	ID              int               `json:"id"`
+	Name            string            `json:"name"`
+	Generation      NamedAPIResource  `json:"generation"`
+	MoveDamageClass *NamedAPIResource `json:"move_damage_class"`
+	DamageRelations struct {
+		DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+		DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+		HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+		HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+		NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+		NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+	Pokemon []struct {
+		Slot    int              `json:"slot"`
+		Pokemon NamedAPIResource `json:"pokemon"`
+	} `json:"pokemon"`
+	Moves []NamedAPIResource `json:"moves"`
+}
+
+// FetchType fetches a type and its damage relations
+func (c *Client) FetchType(name string) (Type, error) {
+	url := fmt.Sprintf("%s/type/%s", c.baseURL, name)
+	t, err := getJSON[Type](c, url, staticTTL)
+	if err != nil {
+		return Type{}, fmt.Errorf("failed to fetch type: %w", err)
+	}
+	return t, nil
+}
+
+// FetchAllTypes fetches every type that takes part in battles, in id
+// order. Types without damage relations such as "unknown" or "shadow"
+// are left out.
+func (c *Client) FetchAllTypes() ([]Type, error) {
+	list, err := c.ListResources("type")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(list))
+	for _, t := range list {
+		names = append(names, t.Name)
+	}
+	fetched, err := FetchConcurrently(names, typeWorkers, c.FetchType)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]Type, 0, len(fetched))
+	for _, t := range fetched {
+		if t.hasDamageRelations() {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].ID < types[j].ID })
+	return types, nil
+}
+
+// hasDamageRelations reports whether the type affects battles at all
+func (t Type) hasDamageRelations() bool {
+	r := t.DamageRelations
+	return len(r.DoubleDamageFrom)+len(r.DoubleDamageTo)+len(r.HalfDamageFrom)+
+		len(r.HalfDamageTo)+len(r.NoDamageFrom)+len(r.NoDamageTo) > 0
+}
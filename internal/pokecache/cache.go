@@ -1,78 +1,140 @@
 package pokecache
 
 import (
-	"sync"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"sync/atomic"
 	"time"
 )
 
-// cacheEntry represents a single cache item
-type cacheEntry struct {
-	createdAt time.Time
-	val       []byte
+// DefaultCompressionThreshold is the body size from which entries are
+// stored gzip-compressed. Small bodies aren't worth the CPU.
+const DefaultCompressionThreshold = 1024
+
+// storedValue is a body as it is kept in memory and on disk
+type storedValue struct {
+	data       []byte
+	compressed bool
+	rawSize    int
 }
 
-// Cache manages a map of cache entries with a mutex for thread-safety
+// Cache stores raw response bodies keyed by URL. Bodies larger than
+// the compression threshold are transparently gzip-compressed.
 type Cache struct {
-	mu       sync.Mutex
-	entries  map[string]cacheEntry
-	interval time.Duration
+	entries   *TypedCache[string, storedValue]
+	threshold atomic.Int64
+}
+
+// Stats describes the footprint of a cache
+type Stats struct {
+	Entries     int
+	Compressed  int
+	RawBytes    int64
+	StoredBytes int64
+}
+
+// Ratio returns the stored size as a fraction of the raw size
+func (s Stats) Ratio() float64 {
+	if s.RawBytes == 0 {
+		return 1
+	}
+	return float64(s.StoredBytes) / float64(s.RawBytes)
 }
 
 // NewCache creates a new cache with a specified cleanup interval
 func NewCache(interval time.Duration) *Cache {
 	cache := &Cache{
-		entries:  make(map[string]cacheEntry),
-		interval: interval,
+		entries: NewTypedCache[string, storedValue](interval),
 	}
-
-	// Start the reap loop in a goroutine
-	go cache.reapLoop()
-
+	cache.threshold.Store(DefaultCompressionThreshold)
 	return cache
 }
 
+// SetCompressionThreshold changes the minimum body size that gets
+// compressed. A threshold < 0 disables compression.
+func (c *Cache) SetCompressionThreshold(threshold int) {
+	c.threshold.Store(int64(threshold))
+}
+
 // Add adds a new entry to the cache
 func (c *Cache) Add(key string, val []byte) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.entries.Add(key, c.encode(val))
+}
 
-	c.entries[key] = cacheEntry{
-		createdAt: time.Now(),
-		val:       val,
-	}
+// AddWithTTL adds a new entry to the cache that expires after ttl
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	c.entries.AddWithTTL(key, c.encode(val), ttl)
 }
 
 // Get retrieves an entry from the cache
 func (c *Cache) Get(key string) ([]byte, bool) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	entry, found := c.entries[key]
+	stored, found := c.entries.Get(key)
 	if !found {
 		return nil, false
 	}
-	return entry.val, true
+
+	val, err := decode(stored)
+	if err != nil {
+		// A corrupt entry is as good as a missing one
+		c.entries.Delete(key)
+		return nil, false
+	}
+	return val, true
 }
 
-// reapLoop periodically removes expired entries from the cache
-func (c *Cache) reapLoop() {
-	ticker := time.NewTicker(c.interval)
-	defer ticker.Stop()
+// Stats reports the number of entries and their raw and stored sizes
+func (c *Cache) Stats() Stats {
+	stats := Stats{}
+	for _, entry := range c.entries.snapshot() {
+		stats.Entries++
+		stats.RawBytes += int64(entry.val.rawSize)
+		stats.StoredBytes += int64(len(entry.val.data))
+		if entry.val.compressed {
+			stats.Compressed++
+		}
+	}
+	return stats
+}
+
+// encode compresses val when it is above the threshold and the
+// compressed form is actually smaller
+func (c *Cache) encode(val []byte) storedValue {
+	stored := storedValue{data: val, rawSize: len(val)}
+
+	threshold := c.threshold.Load()
+	if threshold < 0 || int64(len(val)) < threshold {
+		return stored
+	}
 
-	for range ticker.C {
-		c.reap()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(val); err != nil {
+		return stored
+	}
+	if err := zw.Close(); err != nil {
+		return stored
 	}
+	if buf.Len() >= len(val) {
+		return stored
+	}
+
+	stored.data = buf.Bytes()
+	stored.compressed = true
+	return stored
 }
 
-// reap removes entries older than the cache interval
-func (c *Cache) reap() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// decode returns the raw body of a stored value
+func decode(stored storedValue) ([]byte, error) {
+	if !stored.compressed {
+		return stored.data, nil
+	}
 
-	now := time.Now()
-	for key, entry := range c.entries {
-		if now.Sub(entry.createdAt) > c.interval {
-			delete(c.entries, key)
-		}
+	zr, err := gzip.NewReader(bytes.NewReader(stored.data))
+	if err != nil {
+		return nil, err
 	}
+	defer zr.Close()
+
+	return io.ReadAll(zr)
 }
@@ -2,6 +2,8 @@ package pokecache
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -59,3 +61,135 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestTypedCacheAddGet(t *testing.T) {
+	type species struct {
+		Name        string
+		CaptureRate int
+	}
+
+	cache := NewTypedCache[string, species](5 * time.Second)
+	cache.Add("pikachu", species{Name: "pikachu", CaptureRate: 190})
+
+	val, ok := cache.Get("pikachu")
+	if !ok {
+		t.Fatalf("expected to find key")
+	}
+	if val.Name != "pikachu" || val.CaptureRate != 190 {
+		t.Errorf("expected decoded value, got %+v", val)
+	}
+
+	if _, ok := cache.Get("raichu"); ok {
+		t.Errorf("expected to not find key")
+	}
+}
+
+func TestTypedCacheTTLOverride(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	const waitTime = baseTime + 5*time.Millisecond
+	cache := NewTypedCache[string, int](baseTime)
+	cache.Add("short", 1)
+	cache.AddWithTTL("long", 2, time.Hour)
+
+	time.Sleep(waitTime)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Errorf("expected short-lived key to expire")
+	}
+	val, ok := cache.Get("long")
+	if !ok {
+		t.Fatalf("expected long-lived key to survive the reap")
+	}
+	if val != 2 {
+		t.Errorf("expected 2, got %d", val)
+	}
+}
+
+func TestCacheAddWithTTL(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	const waitTime = baseTime + 5*time.Millisecond
+	cache := NewCache(baseTime)
+	cache.AddWithTTL("https://example.com/pokemon-species/1", []byte("testdata"), time.Hour)
+
+	time.Sleep(waitTime)
+
+	val, ok := cache.Get("https://example.com/pokemon-species/1")
+	if !ok {
+		t.Fatalf("expected to find key")
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected to find value")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "cache.gob")
+
+	cache := NewCache(5 * time.Second)
+	cache.Add("https://example.com", []byte("testdata"))
+	cache.AddWithTTL("https://example.com/static", []byte("static"), time.Hour)
+	if err := cache.Save(path); err != nil {
+		t.Fatalf("expected no error saving, got %v", err)
+	}
+
+	loaded := NewCache(5 * time.Second)
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("expected no error loading, got %v", err)
+	}
+	for _, key := range []string{"https://example.com", "https://example.com/static"} {
+		if _, ok := loaded.Get(key); !ok {
+			t.Errorf("expected to find %s after load", key)
+		}
+	}
+
+	if err := NewCache(time.Second).Load(filepath.Join(t.TempDir(), "missing.gob")); err != nil {
+		t.Errorf("expected missing file to be ignored, got %v", err)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	big := []byte(strings.Repeat(`{"front_default":"https://raw.githubusercontent.com/sprites/1.png"}`, 100))
+	small := []byte("tiny")
+
+	cache := NewCache(5 * time.Second)
+	cache.Add("big", big)
+	cache.Add("small", small)
+
+	val, ok := cache.Get("big")
+	if !ok || string(val) != string(big) {
+		t.Fatalf("expected compressed entry to round-trip")
+	}
+
+	stats := cache.Stats()
+	if stats.Entries != 2 || stats.Compressed != 1 {
+		t.Errorf("expected 2 entries with 1 compressed, got %+v", stats)
+	}
+	if stats.RawBytes != int64(len(big)+len(small)) {
+		t.Errorf("expected raw size %d, got %d", len(big)+len(small), stats.RawBytes)
+	}
+	if stats.Ratio() >= 0.5 {
+		t.Errorf("expected repetitive data to compress well, got ratio %.2f", stats.Ratio())
+	}
+
+	path := filepath.Join(t.TempDir(), "cache.gob")
+	if err := cache.Save(path); err != nil {
+		t.Fatalf("expected no error saving, got %v", err)
+	}
+	loaded := NewCache(5 * time.Second)
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("expected no error loading, got %v", err)
+	}
+	val, ok = loaded.Get("big")
+	if !ok || string(val) != string(big) {
+		t.Errorf("expected compressed entry to survive a save")
+	}
+	if loaded.Stats().StoredBytes != stats.StoredBytes {
+		t.Errorf("expected entries to stay compressed on disk")
+	}
+
+	cache.SetCompressionThreshold(-1)
+	cache.Add("big", big)
+	if cache.Stats().Compressed != 0 {
+		t.Errorf("expected compression to be disabled")
+	}
+}
@@ -0,0 +1,95 @@
+package pokecache
+
+import (
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// diskEntry is the on-disk representation of a cache entry.
+// Val is stored as-is, compressed or not.
+type diskEntry struct {
+	Key        string
+	CreatedAt  time.Time
+	TTL        time.Duration
+	Val        []byte
+	Compressed bool
+	RawSize    int
+}
+
+// Save writes every live entry to the file at path, creating parent
+// directories as needed. The file is replaced atomically.
+func (c *Cache) Save(path string) error {
+	entries := c.entries.snapshot()
+	disk := make([]diskEntry, 0, len(entries))
+	for key, entry := range entries {
+		disk = append(disk, diskEntry{
+			Key:        key,
+			CreatedAt:  entry.createdAt,
+			TTL:        entry.ttl,
+			Val:        entry.val.data,
+			Compressed: entry.val.compressed,
+			RawSize:    entry.val.rawSize,
+		})
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(disk); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads entries previously written by Save. Entries that expired
+// in the meantime are dropped. A missing file is not an error.
+func (c *Cache) Load(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var disk []diskEntry
+	if err := gob.NewDecoder(f).Decode(&disk); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, d := range disk {
+		entry := typedEntry[storedValue]{
+			createdAt: d.CreatedAt,
+			ttl:       d.TTL,
+			val: storedValue{
+				data:       d.Val,
+				compressed: d.Compressed,
+				rawSize:    d.RawSize,
+			},
+		}
+		if entry.expired(now) {
+			continue
+		}
+		if !d.Compressed && d.RawSize == 0 {
+			entry.val.rawSize = len(d.Val)
+		}
+		c.entries.restore(d.Key, entry)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package pokecache
+
+import (
+	"sync"
+	"time"
+)
+
+// typedEntry represents a single decoded cache item with its own lifetime
+type typedEntry[V any] struct {
+	createdAt time.Time
+	ttl       time.Duration
+	val       V
+}
+
+// expired reports whether the entry outlived its ttl at the given time
+func (e typedEntry[V]) expired(now time.Time) bool {
+	return now.Sub(e.createdAt) > e.ttl
+}
+
+// TypedCache keeps values of any type (typically decoded API structs)
+// so hot entries don't need to be unmarshalled again on every hit.
+// Each entry can override the default ttl of the cache.
+type TypedCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	entries  map[K]typedEntry[V]
+	interval time.Duration
+}
+
+// NewTypedCache creates a new typed cache whose entries expire after
+// interval unless added with a specific ttl
+func NewTypedCache[K comparable, V any](interval time.Duration) *TypedCache[K, V] {
+	cache := &TypedCache[K, V]{
+		entries:  make(map[K]typedEntry[V]),
+		interval: interval,
+	}
+
+	// Start the reap loop in a goroutine
+	go cache.reapLoop()
+
+	return cache
+}
+
+// Add adds a new entry to the cache using the default ttl
+func (c *TypedCache[K, V]) Add(key K, val V) {
+	c.AddWithTTL(key, val, c.interval)
+}
+
+// AddWithTTL adds a new entry to the cache that expires after ttl.
+// A ttl <= 0 falls back to the default interval.
+func (c *TypedCache[K, V]) AddWithTTL(key K, val V, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = c.interval
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = typedEntry[V]{
+		createdAt: time.Now(),
+		ttl:       ttl,
+		val:       val,
+	}
+}
+
+// Get retrieves an entry from the cache, ignoring expired entries
+// that have not been reaped yet
+func (c *TypedCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[key]
+	if !found || entry.expired(time.Now()) {
+		var zero V
+		return zero, false
+	}
+	return entry.val, true
+}
+
+// Delete removes an entry from the cache
+func (c *TypedCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// snapshot returns a copy of the live entries
+func (c *TypedCache[K, V]) snapshot() map[K]typedEntry[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	entries := make(map[K]typedEntry[V], len(c.entries))
+	for key, entry := range c.entries {
+		if !entry.expired(now) {
+			entries[key] = entry
+		}
+	}
+	return entries
+}
+
+// restore puts back an entry keeping its original creation time
+func (c *TypedCache[K, V]) restore(key K, entry typedEntry[V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+}
+
+// reapLoop periodically removes expired entries from the cache
+func (c *TypedCache[K, V]) reapLoop() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.reap()
+	}
+}
+
+// reap removes entries older than their ttl
+func (c *TypedCache[K, V]) reap() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range c.entries {
+		if entry.expired(now) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Package query parses and evaluates small filter expressions such as
+//
+//	type=fire and speed>90 and gen<=3
+//
+// against records of named fields. Comparisons are joined with and, or
+// and not, and grouped with parentheses. The operators are = != < <=
+// > >= and ~ (contains). A field with several values, such as the types
+// of a Pokémon, matches when any of its values does, except for != which
+// has to hold for all of them.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value is a field value, either a number or text
+type Value struct {
+	Text    string
+	Number  float64
+	Numeric bool
+}
+
+// Number returns a numeric value
+func Number(n float64) Value {
+	return Value{Text: strconv.FormatFloat(n, 'f', -1, 64), Number: n, Numeric: true}
+}
+
+// Text returns a text value
+func Text(s string) Value {
+	return Value{Text: s}
+}
+
+func (v Value) String() string {
+	return v.Text
+}
+
+// Record maps field names to their values
+type Record map[string][]Value
+
+// Expr is a parsed expression
+type Expr interface {
+	// Eval reports whether the record matches. It fails on fields the
+	// record doesn't have.
+	Eval(r Record) (bool, error)
+	// Fields lists the fields the expression refers to
+	Fields() []string
+}
+
+type andExpr struct{ left, right Expr }
+type orExpr struct{ left, right Expr }
+type notExpr struct{ expr Expr }
+
+// comparison is a field compared to a literal
+type comparison struct {
+	field string
+	op    string
+	value Value
+}
+
+func (e andExpr) Eval(r Record) (bool, error) {
+	ok, err := e.left.Eval(r)
+	if err != nil || !ok {
+		return false, err
+	}
+	return e.right.Eval(r)
+}
+
+func (e andExpr) Fields() []string {
+	return append(e.left.Fields(), e.right.Fields()...)
+}
+
+func (e orExpr) Eval(r Record) (bool, error) {
+	ok, err := e.left.Eval(r)
+	if err != nil || ok {
+		return ok, err
+	}
+	return e.right.Eval(r)
+}
+
+func (e orExpr) Fields() []string {
+	return append(e.left.Fields(), e.right.Fields()...)
+}
+
+func (e notExpr) Eval(r Record) (bool, error) {
+	ok, err := e.expr.Eval(r)
+	return !ok, err
+}
+
+func (e notExpr) Fields() []string {
+	return e.expr.Fields()
+}
+
+func (c comparison) Eval(r Record) (bool, error) {
+	values, ok := r[c.field]
+	if !ok {
+		return false, fmt.Errorf("unknown field %q", c.field)
+	}
+	for _, v := range values {
+		match, err := compare(v, c.op, c.value)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", c.field, err)
+		}
+		// Negated operators must hold for every value: type!=fire
+		// excludes fire/flying as well
+		if c.op == "!=" && !match {
+			return false, nil
+		}
+		if c.op != "!=" && match {
+			return true, nil
+		}
+	}
+	return c.op == "!=", nil
+}
+
+func (c comparison) Fields() []string {
+	return []string{c.field}
+}
+
+// compare applies op to a field value and a literal. Numbers compare
+// numerically, text only supports equality and contains.
+func compare(v Value, op string, lit Value) (bool, error) {
+	if v.Numeric && lit.Numeric {
+		switch op {
+		case "=":
+			return v.Number == lit.Number, nil
+		case "!=":
+			return v.Number != lit.Number, nil
+		case "<":
+			return v.Number < lit.Number, nil
+		case "<=":
+			return v.Number <= lit.Number, nil
+		case ">":
+			return v.Number > lit.Number, nil
+		case ">=":
+			return v.Number >= lit.Number, nil
+		}
+	}
+
+	switch op {
+	case "=":
+		return v.Text == lit.Text, nil
+	case "!=":
+		return v.Text != lit.Text, nil
+	case "~":
+		return strings.Contains(v.Text, lit.Text), nil
+	}
+	if v.Numeric {
+		return false, fmt.Errorf("%q is not a number", lit.Text)
+	}
+	return false, fmt.Errorf("can't use %s on text", op)
+}
+
+// Parse parses an expression
+func Parse(src string) (Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos+1)
+	}
+	return expr, nil
+}
+
+// parser is a recursive descent parser over the tokens of an
+// expression:
+//
+//	or         = and { "or" and }
+//	and        = not { "and" not }
+//	not        = "not" not | primary
+//	primary    = "(" or ")" | comparison
+//	comparison = field op literal
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) or() (Expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isWord("or") {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (Expr, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isWord("and") {
+		p.next()
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) not() (Expr, error) {
+	if p.peek().isWord("not") {
+		p.next()
+		expr, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokLParen:
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, fmt.Errorf("missing ) at %d", closing.pos+1)
+		}
+		return expr, nil
+	case tokWord:
+	default:
+		return nil, fmt.Errorf("expected a field at %d, got %s", t.pos+1, t.describe())
+	}
+
+	op := p.next()
+	if op.kind != tokOp {
+		return nil, fmt.Errorf("expected an operator after %s at %d, got %s", t.text, op.pos+1, op.describe())
+	}
+	lit := p.next()
+	if lit.kind != tokWord && lit.kind != tokString {
+		return nil, fmt.Errorf("expected a value after %s%s at %d, got %s", t.text, op.text, lit.pos+1, lit.describe())
+	}
+
+	value := Text(lit.text)
+	if n, err := strconv.ParseFloat(lit.text, 64); err == nil && lit.kind == tokWord {
+		value = Number(n)
+	}
+	return comparison{field: t.text, op: op.text, value: value}, nil
+}
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func charizard() Record {
+	return Record{
+		"name":  {Text("charizard")},
+		"type":  {Text("fire"), Text("flying")},
+		"speed": {Number(100)},
+		"gen":   {Number(1)},
+	}
+}
+
+func TestEval(t *testing.T) {
+	cases := []struct {
+		src  string
+		want bool
+	}{
+		{"type=fire", true},
+		{"type=flying", true},
+		{"type=water", false},
+		{"type!=water", true},
+		{"type!=flying", false},
+		{"speed>90", true},
+		{"speed>=100 and speed<=100", true},
+		{"speed<100", false},
+		{"speed=100.0", true},
+		{"speed!=100", false},
+		{"type=fire and speed>90 and gen<=3", true},
+		{"type=water or gen=1", true},
+		{"not type=fire", false},
+		{"not (type=water or speed<50)", true},
+		{"type=fire and (gen=2 or speed>99)", true},
+		{"TYPE=fire AND speed>90", false},
+		{"name~zard", true},
+		{"name='charizard'", true},
+		{`name="char"`, false},
+	}
+	for _, c := range cases {
+		expr, err := Parse(c.src)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", c.src, err)
+			continue
+		}
+		got, err := expr.Eval(charizard())
+		if c.src == "TYPE=fire AND speed>90" {
+			// Field names are case sensitive, keywords aren't
+			if err == nil {
+				t.Errorf("Eval(%q) didn't fail on an unknown field", c.src)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Eval(%q) failed: %v", c.src, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("Eval(%q) = %v, want %v", c.src, got, c.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	for _, src := range []string{"weight>10", "name>c", "speed>fast"} {
+		expr, err := Parse(src)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", src, err)
+		}
+		if _, err := expr.Eval(charizard()); err == nil {
+			t.Errorf("Eval(%q) didn't fail", src)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"":                 "empty query",
+		"type=":            "expected a value after type= at 6, got the end",
+		"type fire":        `expected an operator after type at 6, got "fire"`,
+		"(type=fire":       "missing ) at 11",
+		"type=fire speed":  `unexpected "speed" at 11`,
+		"type=fire and":    "expected a field at 14, got the end",
+		"name='char":       "unterminated string at 6",
+		"speed>90 & gen=1": `unexpected '&' at 10`,
+		"=fire":            `expected a field at 1, got "="`,
+	}
+	for src, want := range cases {
+		_, err := Parse(src)
+		if err == nil || err.Error() != want {
+			t.Errorf("Parse(%q) = %v, want %q", src, err, want)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	expr, err := Parse("type=fire and not (speed>90 or gen<=3)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(expr.Fields(), ","); got != "type,speed,gen" {
+		t.Errorf("Fields() = %s, want type,speed,gen", got)
+	}
+}
+
+func TestSort(t *testing.T) {
+	records := []Record{
+		{"name": {Text("pikachu")}, "attack": {Number(55)}},
+		{"name": {Text("raichu")}, "attack": {Number(90)}},
+		{"name": {Text("bulbasaur")}, "attack": {Number(55)}},
+		{"name": {Text("missingno")}},
+	}
+	orders, err := ParseSort("-attack,name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.SortStableFunc(records, func(a, b Record) int { return Compare(a, b, orders) })
+
+	names := []string{}
+	for _, r := range records {
+		names = append(names, r["name"][0].Text)
+	}
+	if got := strings.Join(names, ","); got != "raichu,bulbasaur,pikachu,missingno" {
+		t.Errorf("sorted = %s, want raichu,bulbasaur,pikachu,missingno", got)
+	}
+
+	if _, err := ParseSort("attack,-"); err == nil {
+		t.Error("ParseSort() with an empty field didn't fail")
+	}
+}
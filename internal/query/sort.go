@@ -0,0 +1,68 @@
+package query
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// Order sorts records by a field, descending when Desc
+type Order struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a comma separated list of fields to sort by, each
+// prefixed with - to sort descending, e.g. "-attack,name"
+func ParseSort(src string) ([]Order, error) {
+	orders := []Order{}
+	for _, part := range strings.Split(src, ",") {
+		part = strings.TrimSpace(part)
+		field, desc := strings.CutPrefix(part, "-")
+		if field == "" {
+			return nil, fmt.Errorf("invalid sort %q", src)
+		}
+		orders = append(orders, Order{Field: field, Desc: desc})
+	}
+	return orders, nil
+}
+
+// Compare orders two records by the first value of each field in
+// orders, for use with slices.SortStableFunc. Numbers sort before text
+// and records without a field sort last.
+func Compare(a, b Record, orders []Order) int {
+	for _, o := range orders {
+		va, okA := a[o.Field]
+		vb, okB := b[o.Field]
+		okA, okB = okA && len(va) > 0, okB && len(vb) > 0
+		switch {
+		case !okA && !okB:
+			continue
+		case !okA:
+			return 1
+		case !okB:
+			return -1
+		}
+
+		c := compareValues(va[0], vb[0])
+		if o.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareValues(a, b Value) int {
+	switch {
+	case a.Numeric && b.Numeric:
+		return cmp.Compare(a.Number, b.Number)
+	case a.Numeric:
+		return -1
+	case b.Numeric:
+		return 1
+	}
+	return strings.Compare(a.Text, b.Text)
+}
@@ -0,0 +1,85 @@
+// Package typechart computes type effectiveness from the damage
+// relations of the PokéAPI types.
+package typechart
+
+import (
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// Chart holds the damage multiplier of every attacking type against
+// every defending type
+type Chart struct {
+	types       []string
+	multipliers map[string]map[string]float64
+}
+
+// New builds a chart from the given types, keeping their order. Only
+// the attacking side of the damage relations is needed.
+func New(types []pokeapi.Type) *Chart {
+	c := &Chart{
+		types:       make([]string, 0, len(types)),
+		multipliers: make(map[string]map[string]float64, len(types)),
+	}
+
+	for _, t := range types {
+		c.types = append(c.types, t.Name)
+		row := map[string]float64{}
+		for _, d := range t.DamageRelations.DoubleDamageTo {
+			row[d.Name] = 2
+		}
+		for _, d := range t.DamageRelations.HalfDamageTo {
+			row[d.Name] = 0.5
+		}
+		for _, d := range t.DamageRelations.NoDamageTo {
+			row[d.Name] = 0
+		}
+		c.multipliers[t.Name] = row
+	}
+	return c
+}
+
+// Types returns the type names in chart order
+func (c *Chart) Types() []string {
+	return c.types
+}
+
+// Multiplier returns the damage multiplier of an attacking type against
+// a Pokémon of the given (usually one or two) defending types
+func (c *Chart) Multiplier(attack string, defenders ...string) float64 {
+	multiplier := 1.0
+	for _, defender := range defenders {
+		if m, ok := c.multipliers[attack][defender]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier
+}
+
+// Matchup sorts every attacking type by its effect on a Pokémon of the
+// given defending types
+type Matchup struct {
+	Weaknesses  map[string]float64
+	Resistances map[string]float64
+	Immunities  []string
+}
+
+// Defend computes the matchup of a Pokémon of the given types
+func (c *Chart) Defend(defenders ...string) Matchup {
+	m := Matchup{
+		Weaknesses:  map[string]float64{},
+		Resistances: map[string]float64{},
+		Immunities:  []string{},
+	}
+	for _, attack := range c.types {
+		multiplier := c.Multiplier(attack, defenders...)
+		switch {
+		case multiplier == 0:
+			m.Immunities = append(m.Immunities, attack)
+		case multiplier > 1:
+			m.Weaknesses[attack] = multiplier
+		case multiplier < 1:
+			m.Resistances[attack] = multiplier
+		}
+	}
+	return m
+}
@@ -0,0 +1,83 @@
+package typechart
+
+import (
+	"testing"
+
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// newType builds an attacking type from its damage relations
+func newType(name string, double, half, none []string) pokeapi.Type {
+	refs := func(names []string) []pokeapi.NamedAPIResource {
+		out := []pokeapi.NamedAPIResource{}
+		for _, n := range names {
+			out = append(out, pokeapi.NamedAPIResource{Name: n})
+		}
+		return out
+	}
+
+	t := pokeapi.Type{Name: name}
+	t.DamageRelations.DoubleDamageTo = refs(double)
+	t.DamageRelations.HalfDamageTo = refs(half)
+	t.DamageRelations.NoDamageTo = refs(none)
+	return t
+}
+
+func testChart() *Chart {
+	return New([]pokeapi.Type{
+		newType("normal", nil, []string{"rock"}, []string{"ghost"}),
+		newType("water", []string{"ground", "rock", "fire"}, []string{"water", "grass"}, nil),
+		newType("electric", []string{"water", "flying"}, []string{"grass", "electric"}, []string{"ground"}),
+		newType("grass", []string{"ground", "rock", "water"}, []string{"flying", "grass", "fire"}, nil),
+		newType("ground", []string{"rock", "fire", "electric"}, []string{"grass"}, []string{"flying"}),
+		newType("rock", []string{"flying", "fire"}, []string{"ground"}, nil),
+	})
+}
+
+func TestMultiplier(t *testing.T) {
+	chart := testChart()
+	cases := []struct {
+		attack    string
+		defenders []string
+		expected  float64
+	}{
+		{attack: "water", defenders: []string{"fire"}, expected: 2},
+		{attack: "water", defenders: []string{"rock", "ground"}, expected: 4},
+		{attack: "grass", defenders: []string{"water", "flying"}, expected: 1},
+		{attack: "electric", defenders: []string{"ground"}, expected: 0},
+		{attack: "electric", defenders: []string{"water", "flying"}, expected: 4},
+		{attack: "normal", defenders: []string{"rock", "ghost"}, expected: 0},
+		{attack: "grass", defenders: []string{"grass", "flying"}, expected: 0.25},
+		{attack: "normal", defenders: []string{"water"}, expected: 1},
+		{attack: "shadow", defenders: []string{"water"}, expected: 1},
+	}
+
+	for _, c := range cases {
+		if got := chart.Multiplier(c.attack, c.defenders...); got != c.expected {
+			t.Errorf("%s against %v: expected %g, got %g", c.attack, c.defenders, c.expected, got)
+		}
+	}
+}
+
+func TestDefend(t *testing.T) {
+	chart := testChart()
+
+	// A water/flying pokemon like gyarados
+	m := chart.Defend("water", "flying")
+	if m.Weaknesses["electric"] != 4 || m.Weaknesses["rock"] != 2 {
+		t.Errorf("unexpected weaknesses %v", m.Weaknesses)
+	}
+	if _, ok := m.Weaknesses["grass"]; ok {
+		t.Errorf("expected grass to be neutral, got %v", m.Weaknesses)
+	}
+	if m.Resistances["water"] != 0.5 {
+		t.Errorf("unexpected resistances %v", m.Resistances)
+	}
+	if len(m.Immunities) != 1 || m.Immunities[0] != "ground" {
+		t.Errorf("expected a ground immunity, got %v", m.Immunities)
+	}
+
+	if got := chart.Types(); len(got) != 6 || got[0] != "normal" {
+		t.Errorf("expected types in the given order, got %v", got)
+	}
+}
@@ -1,17 +1,68 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
 )
 
 func main() {
-	pokeClient := pokeapi.NewClient(5*time.Second, 10*time.Minute)
-	cfg := &config{
-		pokeapiClient: pokeClient,
-		caughtPokemon: map[string]pokeapi.PokemonDetails{},
+	opts := []pokeapi.Option{}
+	// Lets the CLI run against cmd/fakepokeapi or any PokéAPI mirror
+	if baseURL := os.Getenv("POKEAPI_BASE_URL"); baseURL != "" {
+		opts = append(opts, pokeapi.WithBaseURL(baseURL))
+	}
+
+	pokeClient := pokeapi.NewClient(5*time.Second, 10*time.Minute, opts...)
+	cfg := newConfig(pokeClient)
+	cfg.cachePath = defaultCachePath()
+	cfg.savePath = defaultSavePath()
+
+	if err := cfg.pokeapiClient.LoadCache(cfg.cachePath); err != nil {
+		fmt.Println("Could not load the cache:", err)
+	}
+	if err := loadGame(cfg); err != nil {
+		fmt.Println("Could not load the save file:", err)
+		os.Exit(1)
+	}
+
+	// Run a single command such as `pokedexcli prefetch` without the REPL.
+	// The shell already split and unquoted the words.
+	if len(os.Args) > 1 {
+		err := runCommand(cfg, os.Args[1:])
+		saveGame(cfg)
+		saveCache(cfg)
+		if err != nil {
+			fmt.Printf("Error executing command: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
 	startRepl(cfg)
+	saveGame(cfg)
+	saveCache(cfg)
+}
+
+// defaultCachePath returns where the response cache is persisted
+// between runs, or "" when there's no user cache directory.
+func defaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokedexcli", "cache.gob")
+}
+
+// saveCache persists the response cache if a cache path is configured
+func saveCache(cfg *config) {
+	if cfg.cachePath == "" {
+		return
+	}
+	if err := cfg.pokeapiClient.SaveCache(cfg.cachePath); err != nil {
+		fmt.Println("Could not save the cache:", err)
+	}
 }
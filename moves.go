@@ -0,0 +1,80 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// moveWorkers bounds the concurrent requests when resolving moves
+const moveWorkers = 8
+
+// learnsetEntry is one way a Pokemon learns a move in a version group
+type learnsetEntry struct {
+	Move         string
+	Method       string
+	Level        int
+	VersionGroup string
+}
+
+// latestVersionGroup returns the most recent version group in which
+// the Pokemon learns any move, going by the version group ids
+func latestVersionGroup(pokemon pokeapi.PokemonDetails) string {
+	latest, latestID := "", -1
+	for _, move := range pokemon.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			id, err := pokeapi.ResourceID(detail.VersionGroup.URL)
+			if err == nil && id > latestID {
+				latest, latestID = detail.VersionGroup.Name, id
+			}
+		}
+	}
+	return latest
+}
+
+// learnset lists the moves of a Pokemon in a version group, optionally
+// restricted to one learn method. Level-up moves come first by level,
+// then the other methods by name.
+func learnset(pokemon pokeapi.PokemonDetails, versionGroup, method string) []learnsetEntry {
+	entries := []learnsetEntry{}
+	for _, move := range pokemon.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if method != "" && detail.MoveLearnMethod.Name != method {
+				continue
+			}
+			entries = append(entries, learnsetEntry{
+				Move:         move.Move.Name,
+				Method:       detail.MoveLearnMethod.Name,
+				Level:        detail.LevelLearnedAt,
+				VersionGroup: versionGroup,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Method != b.Method {
+			if a.Method == "level-up" || b.Method == "level-up" {
+				return a.Method == "level-up"
+			}
+			return a.Method < b.Method
+		}
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		return a.Move < b.Move
+	})
+	return entries
+}
+
+// optionalInt renders a nullable move value
+func optionalInt(i *int) string {
+	if i == nil {
+		return "-"
+	}
+	return strconv.Itoa(*i)
+}
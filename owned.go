@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+)
+
+// findOwned returns the owned Pokemon matching ref: an id such as 3 or
+// #3, a nickname or a species
+func findOwned(conf *config, ref string) []*owned.Pokemon {
+	ref = strings.ToLower(ref)
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		for _, p := range conf.storage.All() {
+			if p.ID == id {
+				return []*owned.Pokemon{p}
+			}
+		}
+		return nil
+	}
+
+	matches := []*owned.Pokemon{}
+	for _, p := range conf.storage.All() {
+		if strings.ToLower(p.Nickname) == ref || p.Species == ref {
+			matches = append(matches, p)
+		}
+	}
+	return matches
+}
+
+// resolveOwned finds the single owned Pokemon matching ref. It returns
+// nil after telling the user when there is none or several.
+func resolveOwned(conf *config, ref string) *owned.Pokemon {
+	matches := findOwned(conf, ref)
+	switch len(matches) {
+	case 0:
+		fmt.Println("you have not caught that pokemon")
+		return nil
+	case 1:
+		return matches[0]
+	}
+
+	fmt.Printf("You have %d pokemon matching %s, use their id:\n", len(matches), ref)
+	for _, p := range matches {
+		fmt.Println(" -", describeOwned(p))
+	}
+	return nil
+}
+
+// describeOwned summarizes an owned Pokemon on one line, e.g.
+// "#3 Sparky (pikachu) Lv. 16 female ★"
+func describeOwned(p *owned.Pokemon) string {
+	s := fmt.Sprintf("#%d %s", p.ID, p.Name())
+	if p.Nickname != "" {
+		s += " (" + p.Species + ")"
+	}
+	s += fmt.Sprintf(" Lv. %d", p.Level)
+	if p.Gender != "genderless" {
+		s += " " + p.Gender
+	}
+	if p.Shiny {
+		s += " ★"
+	}
+	return s
+}
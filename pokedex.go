@@ -0,0 +1,293 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nurusanwe/pokedexcli/internal/dex"
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+	"github.com/nurusanwe/pokedexcli/internal/query"
+)
+
+// dexFilters are the flags of pokedex that keep some of the entries
+var dexFilters = map[string]func(dex.Status) bool{
+	"missing": func(s dex.Status) bool { return s != dex.Caught },
+	"seen":    func(s dex.Status) bool { return s == dex.Seen },
+	"caught":  func(s dex.Status) bool { return s == dex.Caught },
+}
+
+// selectPokedex picks the pokedex to show: the one named by --dex, the
+// main one of the region named by --region, or the national dex
+func selectPokedex(conf *config, flags map[string]string) (string, error) {
+	if name, ok := flags["dex"]; ok {
+		return name, nil
+	}
+	name, ok := flags["region"]
+	if !ok {
+		return "national", nil
+	}
+	region, err := conf.pokeapiClient.FetchRegion(name)
+	if err != nil {
+		return "", err
+	}
+	if len(region.Pokedexes) == 0 {
+		return "", fmt.Errorf("%s has no pokedex", region.Name)
+	}
+	return region.Pokedexes[0].Name, nil
+}
+
+// pokemonSpecies resolves the species of pokemon: the pokedex counts
+// forms such as wormadam-plant under their species. It returns the
+// species it could resolve along with the failures.
+func pokemonSpecies(conf *config, names ...string) ([]string, error) {
+	details, err := pokeapi.FetchConcurrently(names, moveWorkers, conf.pokeapiClient.FetchPokemonDetails)
+	species := make([]string, 0, len(names))
+	for _, name := range names {
+		if d, ok := details[name]; ok {
+			species = append(species, d.Species.Name)
+		}
+	}
+	return species, err
+}
+
+// markSeen registers the species of pokemon as seen, all those it
+// could resolve even when some fail
+func markSeen(conf *config, names ...string) error {
+	species, err := pokemonSpecies(conf, names...)
+	conf.dex.MarkSeen(species...)
+	return err
+}
+
+// describeCompletion renders a completion, e.g. "2/16 caught (12%), 5 seen"
+func describeCompletion(c dex.Completion) string {
+	return fmt.Sprintf("%d/%d caught (%d%%), %d seen", c.Caught, c.Total, c.Percent(), c.Seen)
+}
+
+func commandPokedex(conf *config, args ...string) error {
+	args, flags := parseValueFlags(args, "where", "sort", "limit")
+	for _, name := range []string{"where", "sort", "limit"} {
+		if _, ok := flags[name]; !ok {
+			continue
+		}
+		// A query split over several words would silently lose its end
+		if len(args) > 0 {
+			return fmt.Errorf("unexpected %q, quote the --where query", strings.Join(args, " "))
+		}
+		return queryOwned(conf, flags["where"], flags["sort"], flags["limit"])
+	}
+
+	var keep func(dex.Status) bool
+	for name, filter := range dexFilters {
+		if flags[name] != "true" {
+			continue
+		}
+		if keep != nil {
+			return errors.New("use only one of --missing, --seen and --caught")
+		}
+		keep = filter
+	}
+
+	name, err := selectPokedex(conf, flags)
+	if err != nil {
+		return err
+	}
+	pokedex, err := conf.pokeapiClient.FetchPokedex(name)
+	if err != nil {
+		return err
+	}
+
+	species := make([]string, 0, len(pokedex.PokemonEntries))
+	for _, entry := range pokedex.PokemonEntries {
+		species = append(species, entry.PokemonSpecies.Name)
+	}
+	fmt.Printf("%s pokedex: %s\n", pokedex.Name, describeCompletion(conf.dex.Complete(species)))
+
+	shown := 0
+	for _, entry := range pokedex.PokemonEntries {
+		status := conf.dex.Status(entry.PokemonSpecies.Name)
+		if keep != nil && !keep(status) {
+			continue
+		}
+		shown++
+		line := fmt.Sprintf("#%03d %-12s", entry.EntryNumber, entry.PokemonSpecies.Name)
+		if status != dex.Unseen {
+			line += " " + status.String()
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	if shown == 0 {
+		fmt.Println("No pokemon match")
+	}
+
+	// Regional dexes are a region's completion already, the national
+	// one is broken down by the generation that introduced each species
+	if pokedex.Region != nil {
+		return nil
+	}
+	generations, err := conf.pokeapiClient.FetchAllGenerations()
+	if err != nil {
+		return err
+	}
+	fmt.Println("By generation:")
+	for _, g := range generations {
+		names := make([]string, 0, len(g.PokemonSpecies))
+		for _, s := range g.PokemonSpecies {
+			names = append(names, s.Name)
+		}
+		fmt.Printf(" - %s (%s): %s\n", g.Name, g.MainRegion.Name, describeCompletion(conf.dex.Complete(names)))
+	}
+	return nil
+}
+
+// ownedFields are the fields of the records built by ownedRecord
+var ownedFields = append([]string{
+	"id", "name", "species", "level", "nature", "gender", "shiny",
+	"height", "weight", "base-experience", "gen", "type", "ability", "total",
+}, owned.StatNames...)
+
+// checkFields fails on fields that owned pokemon don't have
+func checkFields(fields []string) error {
+	for _, field := range fields {
+		if !slices.Contains(ownedFields, field) {
+			return fmt.Errorf("unknown field %q, expected one of %s", field, strings.Join(ownedFields, ", "))
+		}
+	}
+	return nil
+}
+
+// ownedRecord builds the query fields of an owned Pokemon: its base
+// stats and species data, and what is particular to it
+func ownedRecord(p *owned.Pokemon, pokemon pokeapi.PokemonDetails, generation int) query.Record {
+	num := func(n int) []query.Value { return []query.Value{query.Number(float64(n))} }
+	text := func(s string) []query.Value { return []query.Value{query.Text(s)} }
+
+	r := query.Record{
+		"id":              num(p.ID),
+		"name":            text(strings.ToLower(p.Name())),
+		"species":         text(p.Species),
+		"level":           num(p.Level),
+		"nature":          text(p.Nature),
+		"gender":          text(p.Gender),
+		"shiny":           text(strconv.FormatBool(p.Shiny)),
+		"height":          num(pokemon.Height),
+		"weight":          num(pokemon.Weight),
+		"base-experience": num(pokemon.BaseExperience),
+		"gen":             num(generation),
+		"type":            {},
+		"ability":         {},
+	}
+	base := owned.BaseStats(pokemon)
+	for _, stat := range owned.StatNames {
+		r[stat] = num(base.Get(stat))
+	}
+	r["total"] = num(base.Total())
+	for _, t := range pokemon.Types {
+		r["type"] = append(r["type"], query.Text(t.Type.Name))
+	}
+	for _, a := range pokemon.Abilities {
+		r["ability"] = append(r["ability"], query.Text(a.Ability.Name))
+	}
+	return r
+}
+
+// queryOwned lists the caught pokemon matching a query, sorted and
+// limited
+func queryOwned(conf *config, where, sortBy, limit string) error {
+	var expr query.Expr
+	if where != "" {
+		var err error
+		if expr, err = query.Parse(where); err != nil {
+			return fmt.Errorf("invalid --where: %w", err)
+		}
+		if err := checkFields(expr.Fields()); err != nil {
+			return err
+		}
+	}
+	orders := []query.Order{{Field: "id"}}
+	if sortBy != "" {
+		parsed, err := query.ParseSort(sortBy)
+		if err != nil {
+			return err
+		}
+		for _, o := range parsed {
+			if err := checkFields([]string{o.Field}); err != nil {
+				return err
+			}
+		}
+		orders = append(parsed, orders...)
+	}
+	maxRows := -1
+	if limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid limit %q", limit)
+		}
+		maxRows = n
+	}
+
+	all := conf.storage.All()
+	species := []string{}
+	for _, p := range all {
+		if !slices.Contains(species, p.Species) {
+			species = append(species, p.Species)
+		}
+	}
+	details, err := pokeapi.FetchConcurrently(species, moveWorkers, conf.pokeapiClient.FetchPokemonDetails)
+	if err != nil {
+		return err
+	}
+
+	type row struct {
+		p      *owned.Pokemon
+		record query.Record
+	}
+	rows := []row{}
+	for _, p := range all {
+		pokemon := details[p.Species]
+		speciesData, err := conf.pokeapiClient.FetchPokemonSpecies(pokemon.Species.Name)
+		if err != nil {
+			return err
+		}
+		generation, err := conf.pokeapiClient.FetchGeneration(speciesData.Generation.Name)
+		if err != nil {
+			return err
+		}
+
+		r := ownedRecord(p, pokemon, generation.ID)
+		if expr != nil {
+			match, err := expr.Eval(r)
+			if err != nil {
+				return err
+			}
+			if !match {
+				continue
+			}
+		}
+		rows = append(rows, row{p, r})
+	}
+	slices.SortStableFunc(rows, func(a, b row) int { return query.Compare(a.record, b.record, orders) })
+	if maxRows >= 0 && len(rows) > maxRows {
+		rows = rows[:maxRows]
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No pokemon match")
+		return nil
+	}
+	fmt.Printf("%-5s %-12s %-3s %-17s %3s %3s %3s %3s %3s %3s %5s\n", "ID", "Name", "Lv", "Types", "HP", "Atk", "Def", "SpA", "SpD", "Spe", "Total")
+	for _, r := range rows {
+		types := []string{}
+		for _, t := range r.record["type"] {
+			types = append(types, t.Text)
+		}
+		stat := func(name string) string { return r.record[name][0].Text }
+		fmt.Printf("%-5s %-12s %-3d %-17s %3s %3s %3s %3s %3s %3s %5s\n",
+			fmt.Sprintf("#%d", r.p.ID), r.p.Name(), r.p.Level, strings.Join(types, "/"),
+			stat("hp"), stat("attack"), stat("defense"), stat("special-attack"), stat("special-defense"), stat("speed"), stat("total"))
+	}
+	return nil
+}
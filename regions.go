@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// regionPageSize is the number of areas map shows per page of a
+// region, the same as the PokéAPI's default page size
+const regionPageSize = 20
+
+// selectMapRegion restricts map and mapb to the areas of a region.
+// The region "all" goes back to the global list of areas.
+func selectMapRegion(conf *config, name string) error {
+	if name == "all" {
+		conf.mapRegion = ""
+		conf.regionAreas = nil
+		return nil
+	}
+	if name == conf.mapRegion {
+		return nil
+	}
+
+	region, err := conf.pokeapiClient.FetchRegion(name)
+	if err != nil {
+		return err
+	}
+	locations, err := conf.pokeapiClient.FetchRegionLocations(region)
+	if err != nil {
+		return err
+	}
+
+	areas := []string{}
+	for _, loc := range locations {
+		for _, area := range loc.Areas {
+			areas = append(areas, area.Name)
+		}
+	}
+	conf.mapRegion = region.Name
+	conf.regionAreas = areas
+	conf.regionPage = -1
+	return nil
+}
+
+// printRegionPage shows a page of the selected region's areas
+func printRegionPage(conf *config, page int) error {
+	if page < 0 {
+		return errors.New("you're on the first page")
+	}
+	start := page * regionPageSize
+	if start >= len(conf.regionAreas) {
+		return errors.New("you're on the last page")
+	}
+	end := min(start+regionPageSize, len(conf.regionAreas))
+
+	conf.regionPage = page
+	for _, area := range conf.regionAreas[start:end] {
+		fmt.Println(area)
+	}
+	return nil
+}
+
+// printLocationTree renders the areas of a location under prefix
+func printLocationTree(loc pokeapi.Location, prefix string) {
+	for i, area := range loc.Areas {
+		if i == len(loc.Areas)-1 {
+			fmt.Println(prefix + "└─ " + area.Name)
+		} else {
+			fmt.Println(prefix + "├─ " + area.Name)
+		}
+	}
+}
@@ -2,9 +2,16 @@ package main
 
 import (
 	"fmt"
+	"math/rand"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/nurusanwe/pokedexcli/internal/dex"
+	"github.com/nurusanwe/pokedexcli/internal/inventory"
+	"github.com/nurusanwe/pokedexcli/internal/owned"
 	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+	"github.com/nurusanwe/pokedexcli/internal/typechart"
 	"github.com/peterh/liner"
 )
 
@@ -12,7 +19,35 @@ type config struct {
 	pokeapiClient    pokeapi.Client
 	nextLocationsURL *string
 	prevLocationsURL *string
-	caughtPokemon    map[string]pokeapi.PokemonDetails
+	mapRegion        string
+	regionAreas      []string
+	regionPage       int
+	storage          *owned.Storage
+	bag              *inventory.Bag
+	dex              *dex.Dex
+	savePath         string
+	cachePath        string
+	language         string
+	rng              *rand.Rand
+	typeChart        *typechart.Chart
+	clock            func() time.Time
+	location         string
+	wild             *wildPokemon
+	battle           *battleState
+	prompt           func(string) (string, error)
+}
+
+// newConfig returns the initial state of a session using client
+func newConfig(client pokeapi.Client) *config {
+	return &config{
+		pokeapiClient: client,
+		storage:       owned.NewStorage(),
+		bag:           inventory.NewBag(),
+		dex:           dex.New(),
+		language:      "en",
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:         time.Now,
+	}
 }
 
 func startRepl(cfg *config) {
@@ -29,6 +64,8 @@ func startRepl(cfg *config) {
 		return
 	})
 
+	cfg.prompt = line.Prompt
+
 	fmt.Println("Welcome to the Pokedex CLI!")
 	fmt.Println("Type 'help' to see available commands.")
 
@@ -44,38 +81,99 @@ func startRepl(cfg *config) {
 		}
 
 		line.AppendHistory(cmd)
-		words := cleanInput(cmd)
+		words := splitInput(cmd)
 
 		if len(words) == 0 {
 			continue
 		}
 
-		firstWord := words[0]
-		command, found := getCommands()[firstWord]
-		if !found {
-			fmt.Printf("Unknown command: %s\n", firstWord)
+		if _, found := getCommands()[strings.ToLower(words[0])]; !found {
+			fmt.Printf("Unknown command: %s\n", words[0])
 			continue
 		}
 
-		err = command.callback(cfg, words[1:]...)
+		err = runCommand(cfg, words)
 		if err != nil {
 			fmt.Printf("Error executing command: %v\n", err)
 		}
 	}
 }
 
+// runCommand looks up the command named by the first word and calls it
+// with the remaining words as arguments, lowercased unless the command
+// keeps their case.
+func runCommand(cfg *config, words []string) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	firstWord := strings.ToLower(words[0])
+	command, found := getCommands()[firstWord]
+	if !found {
+		return fmt.Errorf("unknown command: %s", firstWord)
+	}
+
+	args := words[1:]
+	if !command.keepCase {
+		args = lowerWords(args)
+	}
+	return command.callback(cfg, args...)
+}
+
+// cleanInput lowercases a line and splits it into words. Single or
+// double quotes keep spaces in a word, as in --where='speed > 90'.
 func cleanInput(text string) []string {
-	words := strings.Fields(text)
-	for i, word := range words {
-		words[i] = strings.ToLower(strings.TrimSpace(word))
+	return lowerWords(splitInput(text))
+}
+
+// lowerWords returns a lowercased copy of words
+func lowerWords(words []string) []string {
+	lower := make([]string, 0, len(words))
+	for _, w := range words {
+		lower = append(lower, strings.ToLower(w))
+	}
+	return lower
+}
+
+// splitInput splits a line into words like cleanInput, keeping their
+// case
+func splitInput(text string) []string {
+	words := []string{}
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	for _, r := range text {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case unicode.IsSpace(r):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
 	}
 	return words
 }
 
+// cliCommand is a command of the REPL. The arguments of commands that
+// keepCase are passed as typed, e.g. nicknames.
 type cliCommand struct {
 	name        string
 	description string
 	callback    func(*config, ...string) error
+	keepCase    bool
 }
 
 func getCommands() map[string]cliCommand {
@@ -91,8 +189,8 @@ func getCommands() map[string]cliCommand {
 			callback:    commandExit,
 		},
 		"map": {
-			name:        "map",
-			description: "Display the next 20 location areas",
+			name:        "map [--region=x]",
+			description: "Display the next 20 location areas, optionally of one region (all for every region)",
 			callback:    commandMap,
 		},
 		"mapb": {
@@ -101,24 +199,190 @@ func getCommands() map[string]cliCommand {
 			callback:    commandMapBack,
 		},
 		"explore": {
-			name:        "explore <location>",
-			description: "Display the pokemon in a location",
+			name:        "explore <location> [--version=x] [--method=x]",
+			description: "Display the pokemon in a location with their levels and chances",
 			callback:    commandExplore,
 		},
 		"catch": {
-			name:        "catch <pokemon>",
+			name:        "catch [pokemon] [--ball=x] [--status=x] [--hp=n%] [--free-catch] [--level=n]",
 			description: "Attempt to catch a pokemon",
 			callback:    commandCatch,
 		},
 		"inspect": {
-			name:        "inspect <pokemon>",
+			name:        "inspect <pokemon|id> [--version=x]",
 			description: "Provide details on a caught pokemon",
 			callback:    commandInspect,
 		},
+		"compare": {
+			name:        "compare <pokemon> <pokemon> [...]",
+			description: "Compare the base stats, types, abilities and matchups of pokemon side by side",
+			callback:    commandCompare,
+		},
 		"pokedex": {
-			name:        "pokedex",
-			description: "Provide the list of caught pokemon",
+			name:        "pokedex [--dex=x|--region=x] [--missing|--seen|--caught] [--where 'query'] [--sort=-field] [--limit n]",
+			description: "Show the pokemon seen and caught, in pokedex order, or query the caught ones, e.g. --where 'type=fire and speed>90'",
 			callback:    commandPokedex,
 		},
+		"search": {
+			name:        "search <text> [--in=pokemon,move,item,location-area,ability] [--limit n]",
+			description: "Find pokemon, moves, items, areas and abilities by name, tolerating typos",
+			callback:    commandSearch,
+		},
+		"prefetch": {
+			name:        "prefetch",
+			description: "Download every location area, its pokemon and every type for offline use",
+			callback:    commandPrefetch,
+		},
+		"cache": {
+			name:        "cache",
+			description: "Show the size and compression ratio of the cache",
+			callback:    commandCache,
+		},
+		"language": {
+			name:        "language [code]",
+			description: "Show or set the language of descriptions, e.g. en or de",
+			callback:    commandLanguage,
+		},
+		"evolution": {
+			name:        "evolution <pokemon>",
+			description: "Show the evolution tree of a pokemon",
+			callback:    commandEvolution,
+		},
+		"evolve": {
+			name:        "evolve <pokemon|id> [--item=x] [--trade] [--into=x]",
+			description: "Evolve a caught pokemon whose conditions are met, using up the item from the bag",
+			callback:    commandEvolve,
+		},
+		"types": {
+			name:        "types",
+			description: "Show the type effectiveness chart",
+			callback:    commandTypes,
+		},
+		"weak": {
+			name:        "weak <pokemon>",
+			description: "Show the weaknesses, resistances and immunities of a pokemon",
+			callback:    commandWeak,
+		},
+		"moves": {
+			name:        "moves <pokemon> [--version-group=x] [--method=x]",
+			description: "Show the moves a pokemon can learn",
+			callback:    commandMoves,
+		},
+		"ability": {
+			name:        "ability <name>",
+			description: "Describe an ability and list the pokemon that can have it",
+			callback:    commandAbility,
+		},
+		"item": {
+			name:        "item <name>",
+			description: "Show an item's cost, effect and the wild pokemon holding it",
+			callback:    commandItem,
+		},
+		"berries": {
+			name:        "berries [--flavor=x]",
+			description: "List berries with their growth time, flavors and natural gift",
+			callback:    commandBerries,
+		},
+		"region": {
+			name:        "region <name>",
+			description: "Show the locations and areas of a region",
+			callback:    commandRegion,
+		},
+		"location": {
+			name:        "location <name>",
+			description: "Show the region and areas of a location",
+			callback:    commandLocation,
+		},
+		"where": {
+			name:        "where <pokemon> [--version=x]",
+			description: "List the location areas where a pokemon can be found",
+			callback:    commandWhere,
+		},
+		"goto": {
+			name:        "goto <area>",
+			description: "Travel to a location area",
+			callback:    commandGoto,
+		},
+		"walk": {
+			name:        "walk",
+			description: "Walk through the tall grass of the current area",
+			callback:    commandWalk,
+		},
+		"surf": {
+			name:        "surf",
+			description: "Surf on the water of the current area",
+			callback:    commandSurf,
+		},
+		"fish": {
+			name:        "fish [--rod=old|good|super]",
+			description: "Fish in the current area",
+			callback:    commandFish,
+		},
+		"battle": {
+			name:        "battle [pokemon|id] [--trainer]",
+			description: "Battle the wild pokemon in front of you, or a trainer",
+			callback:    commandBattle,
+		},
+		"attack": {
+			name:        "attack <move|n>",
+			description: "Use a move in the current battle",
+			callback:    commandAttack,
+		},
+		"run": {
+			name:        "run",
+			description: "Flee from the current battle",
+			callback:    commandRun,
+		},
+		"learn": {
+			name:        "learn <pokemon|id> [move] [--forget=move]",
+			description: "List the level-up moves a pokemon can learn, or teach it one",
+			callback:    commandLearn,
+		},
+		"shop": {
+			name:        "shop [buy|sell <item> [quantity]]",
+			description: "List the Poke Mart's wares, or buy and sell items",
+			callback:    commandShop,
+		},
+		"bag": {
+			name:        "bag",
+			description: "List your items and money",
+			callback:    commandBag,
+		},
+		"party": {
+			name:        "party",
+			description: "List the pokemon in your party",
+			callback:    commandParty,
+		},
+		"box": {
+			name:        "box [n]",
+			description: "List the pokemon in a PC box, box 1 by default",
+			callback:    commandBox,
+		},
+		"deposit": {
+			name:        "deposit <pokemon|id> [--box=n]",
+			description: "Move a party pokemon to a PC box",
+			callback:    commandDeposit,
+		},
+		"withdraw": {
+			name:        "withdraw <pokemon|id>",
+			description: "Move a pokemon from a PC box to your party",
+			callback:    commandWithdraw,
+		},
+		"swap": {
+			name:        "swap <pokemon|id> <pokemon|id>",
+			description: "Swap the places of two pokemon in your party or boxes",
+			callback:    commandSwap,
+		},
+		"release": {
+			name:        "release <pokemon|id>",
+			description: "Release a pokemon for good",
+			callback:    commandRelease,
+		},
+		"nickname": {
+			name:        "nickname <pokemon|id> [name]",
+			description: "Give a pokemon a nickname, or remove it",
+			callback:    commandNickname,
+			keepCase:    true,
+		},
 	}
 }
@@ -1,7 +1,29 @@
 package main
 
 import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/nurusanwe/pokedexcli/internal/dex"
+	"github.com/nurusanwe/pokedexcli/internal/httpfixture"
+	"github.com/nurusanwe/pokedexcli/internal/inventory"
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi/pokeapitest"
 )
 
 func TestCleanInput(t *testing.T) {
@@ -17,6 +39,18 @@ func TestCleanInput(t *testing.T) {
 			input:    "Charmander Bulbasaur PIKACHU ",
 			expected: []string{"charmander", "bulbasaur", "pikachu"},
 		},
+		{
+			input:    `pokedex --where 'type=fire and Speed>90' --sort="-attack"`,
+			expected: []string{"pokedex", "--where", "type=fire and speed>90", "--sort=-attack"},
+		},
+		{
+			input:    `nickname 3 "mr  mime" ''`,
+			expected: []string{"nickname", "3", "mr  mime", ""},
+		},
+	}
+
+	if words := splitInput(`Nickname 1 "Mr  Mime"`); !reflect.DeepEqual(words, []string{"Nickname", "1", "Mr  Mime"}) {
+		t.Errorf("expected splitInput to keep the case, got %q", words)
 	}
 
 	for _, c := range cases {
@@ -39,3 +73,1183 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+var record = flag.Bool("record", false, "record fixtures from the live PokéAPI instead of replaying them")
+
+// newFixtureConfig returns a config whose client replays the fixtures
+// in testdata/fixtures, or records them again with -record.
+func newFixtureConfig(t *testing.T) *config {
+	t.Helper()
+
+	var transport http.RoundTripper = httpfixture.NewReplayer("testdata/fixtures")
+	if *record {
+		transport = httpfixture.NewRecorder("testdata/fixtures", nil)
+	}
+
+	return newConfig(pokeapi.NewClient(5*time.Second, time.Minute, pokeapi.WithTransport(transport)))
+}
+
+// captureOutput returns everything fn writes to stdout
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		out <- buf.String()
+	}()
+
+	fn()
+	w.Close()
+	return <-out
+}
+
+// runInput runs a REPL line against cfg and returns its output
+func runInput(t *testing.T, cfg *config, input string) (string, error) {
+	t.Helper()
+
+	var err error
+	output := captureOutput(t, func() {
+		err = runCommand(cfg, splitInput(input))
+	})
+	return output, err
+}
+
+func TestReplEndToEnd(t *testing.T) {
+	cfg := newFixtureConfig(t)
+
+	cases := []struct {
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{input: "map", expected: []string{"canalave-city-area", "mt-coronet-1f-from-exterior"}},
+		{input: "map", expected: []string{"mt-coronet-b1f", "solaceon-ruins-b3f-c"}},
+		{input: "mapb", expected: []string{"canalave-city-area"}},
+		{input: "explore pastoria-city-area", expected: []string{"Exploring pastoria-city-area...", "\nmagikarp ", "\ngastrodon "}},
+		{input: "catch missingno --free-catch", wantErr: true},
+		{input: "catch pikachu --ball=beach-ball --free-catch", wantErr: true},
+		{input: "catch Pikachu --ball=master-ball --free-catch", expected: []string{"Throwing a master-ball at pikachu...", "Gotcha! pikachu was caught!"}},
+		{input: "inspect pikachu --version=red", expected: []string{"Genus: Mouse Pokémon", "lightning storms."}},
+		{input: "dance", wantErr: true},
+	}
+
+	for _, c := range cases {
+		output, err := runInput(t, cfg, c.input)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error, got none", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", c.input, err)
+		}
+		for _, want := range c.expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("%q: expected output to contain %q, got:\n%s", c.input, want, output)
+			}
+		}
+	}
+}
+
+// newFakeServerConfig returns a config talking to a fake PokéAPI that
+// is shut down at the end of the test. Its random numbers are seeded.
+func newFakeServerConfig(t *testing.T) *config {
+	t.Helper()
+
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	t.Cleanup(server.Close)
+
+	cfg := newConfig(pokeapi.NewClient(5*time.Second, time.Minute, pokeapi.WithBaseURL(server.BaseURL())))
+	cfg.rng = rand.New(rand.NewSource(1))
+	return cfg
+}
+
+// newFailingServerConfig is like newFakeServerConfig, except that the
+// requests under any of the given resources fail with a server error
+func newFailingServerConfig(t *testing.T, resources ...string) *config {
+	t.Helper()
+
+	h, err := pokeapitest.NewHandler(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, resource := range resources {
+			if strings.HasPrefix(r.URL.Path, "/api/v2/"+resource+"/") {
+				http.Error(w, "unavailable", http.StatusInternalServerError)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := newConfig(pokeapi.NewClient(5*time.Second, time.Minute, pokeapi.WithBaseURL(server.URL+"/api/v2")))
+	cfg.rng = rand.New(rand.NewSource(1))
+	return cfg
+}
+
+func TestReplAgainstFakeServer(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "map")
+	if err != nil || !strings.Contains(output, "canalave-city-area") {
+		t.Fatalf("expected the first page of areas, got %v:\n%s", err, output)
+	}
+	output, err = runInput(t, cfg, "map")
+	if err != nil || !strings.Contains(output, "cerulean-cave-1f") {
+		t.Fatalf("expected the second page of areas, got %v:\n%s", err, output)
+	}
+	output, err = runInput(t, cfg, "explore oreburgh-mine-1f")
+	if err != nil || !strings.Contains(output, "\ngeodude ") {
+		t.Fatalf("expected geodude in the mine, got %v:\n%s", err, output)
+	}
+	if _, err := runInput(t, cfg, "explore nowhere"); err == nil {
+		t.Error("expected an error for an unknown area")
+	}
+
+	cfg.storage.Add(owned.Pokemon{Species: "pikachu", Level: 5, Nature: "hardy"})
+
+	output, err = runInput(t, cfg, "inspect pikachu --version=red")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{"Genus: Mouse Pokémon", "Pokedex entry (red):", "When several of these POKéMON gather"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected inspect to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, _ = runInput(t, cfg, "inspect pikachu --version=diamond")
+	if !strings.Contains(output, "Pokedex entry (diamond):") {
+		t.Errorf("expected the chosen version's entry, got:\n%s", output)
+	}
+}
+
+func TestInspectWithoutSpecies(t *testing.T) {
+	cfg := newFailingServerConfig(t, "pokemon-species")
+	cfg.storage.Add(owned.Pokemon{Species: "pikachu", Level: 5, Nature: "hardy"})
+
+	output, err := runInput(t, cfg, "inspect pikachu")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{"Name: pikachu\n", "Level: 5\n", " - electric\n", "No Pokedex entry:"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected inspect to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Genus:") {
+		t.Errorf("expected no genus without the species, got:\n%s", output)
+	}
+}
+
+func TestCatchWithoutGrowthRate(t *testing.T) {
+	cfg := newFailingServerConfig(t, "growth-rate")
+
+	for _, input := range []string{"goto kanto-route-1-area", "walk"} {
+		if _, err := runInput(t, cfg, input); err != nil {
+			t.Fatalf("%q: expected no error, got %v", input, err)
+		}
+	}
+	wild := cfg.wild.Name
+	output, err := runInput(t, cfg, "catch "+wild)
+	if err == nil {
+		t.Fatal("expected an error without the growth rate")
+	}
+	if strings.Contains(output, "Throwing") || cfg.bag.Count("poke-ball") != 5 || cfg.wild == nil {
+		t.Errorf("expected no ball thrown, got %d balls left:\n%s", cfg.bag.Count("poke-ball"), output)
+	}
+	if len(cfg.storage.All()) != 0 || cfg.dex.Status(wild) == dex.Caught {
+		t.Errorf("expected nothing caught, got %+v", cfg.storage.All())
+	}
+
+	cfg.storage.Add(owned.Pokemon{Species: "pikachu", Level: 5, Nature: "hardy"})
+	output, err = runInput(t, cfg, "inspect pikachu")
+	if err != nil || !strings.Contains(output, "Level: 5\n") || strings.Contains(output, "Exp:") {
+		t.Errorf("expected inspect without the exp line, got %v:\n%s", err, output)
+	}
+}
+
+func TestPrefetchOffline(t *testing.T) {
+	server, err := pokeapitest.NewServer(pokeapitest.Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	cfg := newConfig(pokeapi.NewClient(5*time.Second, time.Minute, pokeapi.WithBaseURL(server.BaseURL())))
+	cfg.rng = rand.New(rand.NewSource(1))
+
+	opts := pokeapi.DefaultPrefetchOptions()
+	opts.RequestsPerSecond = 0
+	if _, err := cfg.pokeapiClient.Prefetch(opts); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// pikachu lives in viridian-forest-area
+	server.Close()
+	for _, input := range []string{"catch pikachu --free-catch --ball=master-ball", "inspect pikachu", "weak pikachu"} {
+		if output, err := runInput(t, cfg, input); err != nil {
+			t.Errorf("%q: expected no error offline, got %v:\n%s", input, err, output)
+		}
+	}
+}
+
+func TestTypeCommands(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "weak gyarados")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{
+		"gyarados (water/flying)",
+		"Weaknesses: electric (4x), rock (2x)",
+		"Immunities: ground",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = runInput(t, cfg, "types")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Contains(output, "unknown") {
+		t.Errorf("expected types without damage relations to be left out, got:\n%s", output)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 18+3 {
+		t.Errorf("expected a header, 18 rows and a legend, got %d lines", len(lines))
+	}
+}
+
+func TestMovesCommand(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "moves pikachu --version-group=red-blue --method=level-up")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2+4 {
+		t.Fatalf("expected a title, a header and 4 moves, got:\n%s", output)
+	}
+	for i, want := range []string{"growl", "thunder-shock", "thunder-wave", "quick-attack"} {
+		if fields := strings.Fields(lines[2+i]); fields[1] != want {
+			t.Errorf("expected move %d to be %s, got %s", i+1, want, fields[1])
+		}
+	}
+	if !strings.HasPrefix(lines[5], "16 ") {
+		t.Errorf("expected quick-attack at level 16, got %q", lines[5])
+	}
+	if !strings.HasSuffix(lines[3], "level-up  Has a 10% chance to paralyze the target.") {
+		t.Errorf("expected the effect of thunder-shock, got %q", lines[3])
+	}
+
+	output, err = runInput(t, cfg, "moves pikachu")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "in diamond-pearl") || !strings.Contains(output, "thunderbolt") {
+		t.Errorf("expected the latest version group with machine moves, got:\n%s", output)
+	}
+}
+
+func TestAbilityCommands(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "ability keen-eye")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{
+		"Prevents accuracy from being lowered.",
+		" - wingull\n",
+		" - starly\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	cfg.storage.Add(owned.Pokemon{Species: "bulbasaur", Level: 5, Nature: "hardy"})
+	output, err = runInput(t, cfg, "inspect bulbasaur")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "Abilities:\n - overgrow\n - chlorophyll (hidden)\n") {
+		t.Errorf("expected the hidden ability to be marked, got:\n%s", output)
+	}
+}
+
+func TestItemCommands(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "item light-ball")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{
+		"Cost: 1000",
+		"Fling power: 30",
+		"Held by wild pokemon:\n - pikachu: 5% in diamond\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = runInput(t, cfg, "berries --flavor=sour")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 1+5 || !strings.HasPrefix(lines[1], "aspear") {
+		t.Errorf("expected a header and the 5 sour berries, got:\n%s", output)
+	}
+}
+
+func TestRegionCommands(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "location oreburgh-mine")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if output != "oreburgh-mine (sinnoh)\n├─ oreburgh-mine-1f\n└─ oreburgh-mine-b1f\n" {
+		t.Errorf("unexpected location tree:\n%s", output)
+	}
+
+	output, err = runInput(t, cfg, "region kanto")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{"kanto (generation-i)\n", "├─ mt-moon\n│  └─ mt-moon-1f\n", "└─ kanto-safari-zone\n   └─ kanto-safari-zone-area-1-east\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = runInput(t, cfg, "map --region=kanto")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 7 || lines[0] != "pallet-town-area" {
+		t.Errorf("expected the 7 kanto areas, got:\n%s", output)
+	}
+	if _, err := runInput(t, cfg, "map"); err == nil {
+		t.Error("expected an error past the last page of the region")
+	}
+	if _, err := runInput(t, cfg, "mapb"); err == nil {
+		t.Error("expected an error before the first page of the region")
+	}
+
+	output, err = runInput(t, cfg, "map --region=all")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(output, "canalave-city-area\n") {
+		t.Errorf("expected the global list again, got:\n%s", output)
+	}
+}
+
+func TestWhereCommand(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "where pikachu --version=red")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2 || strings.Join(strings.Fields(lines[1]), " ") != "viridian-forest-area red walk 3-5 5%" {
+		t.Errorf("expected a single row for viridian forest, got:\n%s", output)
+	}
+
+	output, err = runInput(t, cfg, "where magikarp")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "pastoria-city-area") || !strings.Contains(output, "good-rod") {
+		t.Errorf("expected every fishing spot, got:\n%s", output)
+	}
+
+	output, err = runInput(t, cfg, "where charmander")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if output != "charmander can't be found in the wild\n" {
+		t.Errorf("unexpected output %q", output)
+	}
+}
+
+func TestExploreTable(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "explore eterna-forest-area --version=diamond --method=walk")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	rows := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n")[3:] {
+		rows[strings.Join(strings.Fields(line), " ")] = true
+	}
+	for _, want := range []string{
+		"budew diamond walk 10-12 30%",
+		"starly diamond walk 10-12 20% during the day",
+		"starly diamond walk 10-12 10% at night",
+		"eevee diamond walk 10-12 10% during a swarm",
+	} {
+		if !rows[want] {
+			t.Errorf("expected a row %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = runInput(t, cfg, "explore oreburgh-mine-1f --version=platinum")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Contains(output, "diamond") || !strings.Contains(output, "70%") {
+		t.Errorf("expected only the platinum encounters, got:\n%s", output)
+	}
+
+	output, err = runInput(t, cfg, "explore eterna-forest-area --method=surf")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "No Pokemon found") {
+		t.Errorf("expected nothing to surf on, got:\n%s", output)
+	}
+}
+
+func TestSummarizeEncounters(t *testing.T) {
+	walk := pokeapi.NamedAPIResource{Name: "walk"}
+	morning := []pokeapi.NamedAPIResource{{Name: "time-morning"}}
+	summaries := summarizeEncounters([]pokeapi.Encounter{
+		{MinLevel: 3, MaxLevel: 5, Chance: 60, Method: walk},
+		{MinLevel: 2, MaxLevel: 4, Chance: 50, Method: walk},
+		{MinLevel: 7, MaxLevel: 7, Chance: 10, Method: walk, ConditionValues: morning},
+	})
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 summaries, got %+v", summaries)
+	}
+	if s := summaries[0]; s.MinLevel != 2 || s.MaxLevel != 5 || s.Chance != 100 {
+		t.Errorf("expected levels 2-5 capped at 100%%, got %+v", s)
+	}
+	if got := describeConditions(summaries[1].Conditions); got != "in the morning" {
+		t.Errorf("expected in the morning, got %q", got)
+	}
+}
+
+func TestWildEncounters(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	cfg.clock = func() time.Time { return time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC) }
+
+	if _, err := runInput(t, cfg, "walk"); err == nil {
+		t.Error("expected an error before going anywhere")
+	}
+	if _, err := runInput(t, cfg, "catch bidoof"); err == nil {
+		t.Error("expected an error without a wild pokemon")
+	}
+
+	if _, err := runInput(t, cfg, "goto kanto-route-1-area"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	output, err := runInput(t, cfg, "fish --rod=super")
+	if err != nil || output != "No wild pokemon can be found with super-rod here\n" {
+		t.Errorf("expected nothing to fish, got %v: %q", err, output)
+	}
+
+	output, err = runInput(t, cfg, "walk")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(output, "A wild bidoof (Lv. ") || cfg.wild == nil {
+		t.Fatalf("expected a wild bidoof, got %q", output)
+	}
+	if cfg.wild.Level < 2 || cfg.wild.Level > 5 {
+		t.Errorf("expected a level between 2 and 5, got %d", cfg.wild.Level)
+	}
+
+	if _, err := runInput(t, cfg, "catch pikachu"); err == nil {
+		t.Error("expected an error when catching another pokemon")
+	}
+	if _, err := runInput(t, cfg, "catch --ball=master-ball"); err == nil {
+		t.Error("expected an error without a master-ball in the bag")
+	}
+	cfg.bag.Add("master-ball", 1)
+	output, err = runInput(t, cfg, "catch --ball=master-ball")
+	if err != nil || !strings.Contains(output, "Gotcha! bidoof was caught!") {
+		t.Fatalf("expected to catch the wild bidoof, got %v:\n%s", err, output)
+	}
+	if cfg.wild != nil {
+		t.Error("expected the wild pokemon to be gone once caught")
+	}
+}
+
+func TestRollEncounter(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	area, err := cfg.pokeapiClient.ListExplore("eterna-forest-area")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	night := time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		wild, found := rollEncounter(cfg.rng, area, "diamond", "walk", night)
+		if !found {
+			t.Fatal("expected an encounter")
+		}
+		counts[wild.Name]++
+	}
+
+	// Budew and bidoof have 30% each and night starly 10%. Day starly
+	// and swarming eevee can't appear.
+	if counts["eevee"] != 0 {
+		t.Errorf("expected no eevee outside of a swarm, got %d", counts["eevee"])
+	}
+	if counts["starly"] < 80 || counts["starly"] > 200 {
+		t.Errorf("expected about 1 in 7 starly at night, got %d", counts["starly"])
+	}
+	if counts["budew"] < 350 || counts["bidoof"] < 350 {
+		t.Errorf("expected budew and bidoof to dominate, got %v", counts)
+	}
+
+	if _, found := rollEncounter(cfg.rng, area, "diamond", "surf", night); found {
+		t.Error("expected no surfing encounters in a forest")
+	}
+}
+
+func TestOwnedPokemon(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	cfg.location = "viridian-forest-area"
+
+	for i := 0; i < 2; i++ {
+		output, err := runInput(t, cfg, "catch pikachu --ball=master-ball --free-catch --level=20")
+		if err != nil || !strings.Contains(output, "Gotcha! pikachu was caught!") {
+			t.Fatalf("expected to catch pikachu again, got %v:\n%s", err, output)
+		}
+	}
+	party := cfg.storage.Party
+	if len(party) != 2 || party[0].ID == party[1].ID {
+		t.Fatalf("expected two pikachu with their own ids, got %+v", party)
+	}
+
+	output, err := runInput(t, cfg, "inspect pikachu")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "You have 2 pokemon matching pikachu, use their id:") {
+		t.Errorf("expected an ambiguous match, got:\n%s", output)
+	}
+
+	p := party[1]
+	p.Nature = "timid"
+	p.IVs = owned.Stats{HP: 31, Attack: 31, Defense: 31, SpecialAttack: 31, SpecialDefense: 31, Speed: 31}
+	p.EVs = owned.Stats{Speed: 252}
+	output, err = runInput(t, cfg, fmt.Sprintf("inspect #%d", p.ID))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{
+		"Level: 20\n",
+		"Nature: timid (+speed, -attack)\n",
+		"Caught: at viridian-forest-area",
+		" - hp: 50 (base 35, IV 31, EV 0)\n",
+		" - attack: 29 (base 55, IV 31, EV 0)\n",
+		" - speed: 64 (base 90, IV 31, EV 252)\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected inspect to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, _ = runInput(t, cfg, "catch")
+	if strings.Count(output, " pikachu Lv. 20") != 2 {
+		t.Errorf("expected both pikachu in the caught list, got:\n%s", output)
+	}
+}
+
+func TestPartyAndBoxes(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	cfg.clock = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	cfg.savePath = filepath.Join(t.TempDir(), "save.json")
+	cfg.location = "eterna-forest-area"
+
+	for i := 0; i < owned.PartySize+1; i++ {
+		if _, err := runInput(t, cfg, "catch budew --ball=master-ball --free-catch"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	output, _ := runInput(t, cfg, "party")
+	if !strings.HasPrefix(output, "Your party (6/6):\n 1. #1 budew Lv. 5") {
+		t.Errorf("expected a full party, got:\n%s", output)
+	}
+	output, _ = runInput(t, cfg, "box")
+	if !strings.Contains(output, "Box 1 (1/30):\n - #7 budew") {
+		t.Errorf("expected the seventh catch in box 1, got:\n%s", output)
+	}
+
+	for _, input := range []string{"nickname 1 42", "nickname 1 '#2'"} {
+		if _, err := runInput(t, cfg, input); err == nil {
+			t.Errorf("%q: expected an error for a numeric nickname", input)
+		}
+	}
+	for _, input := range []string{"nickname 1 Sprout", "deposit sprout --box=2", "withdraw 7", "swap 2 7", "release 3"} {
+		if _, err := runInput(t, cfg, input); err != nil {
+			t.Fatalf("%q: expected no error, got %v", input, err)
+		}
+	}
+	output, _ = runInput(t, cfg, "party")
+	if !strings.Contains(output, " 1. #7 budew") || strings.Contains(output, "#3 ") {
+		t.Errorf("expected #7 in the first slot and #3 released, got:\n%s", output)
+	}
+	output, _ = runInput(t, cfg, "box 2")
+	if !strings.Contains(output, " - #1 Sprout (budew) Lv. 5") {
+		t.Errorf("expected sprout in box 2, got:\n%s", output)
+	}
+
+	if err := writeGame(cfg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	restored := newFakeServerConfig(t)
+	restored.savePath = cfg.savePath
+	if err := loadGame(restored); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(restored.storage, cfg.storage) {
+		t.Errorf("expected the storage to round-trip, got %+v, want %+v", restored.storage, cfg.storage)
+	}
+	if restored.location != "eterna-forest-area" {
+		t.Errorf("expected the location to round-trip, got %q", restored.location)
+	}
+
+	// The next catch must not reuse an id
+	runInput(t, restored, "catch budew --ball=master-ball --free-catch")
+	if ids := findOwned(restored, "#8"); len(ids) != 1 {
+		t.Errorf("expected the next catch to get id 8")
+	}
+}
+
+func TestBattle(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	if _, err := runInput(t, cfg, "battle"); err == nil {
+		t.Error("expected an error without any pokemon")
+	}
+	output, err := runInput(t, cfg, "catch pikachu --free-catch --level=12 --ball=master-ball")
+	if err != nil {
+		t.Fatalf("expected no error, got %v:\n%s", err, output)
+	}
+	pikachu := cfg.storage.Party[0]
+	want := []string{"growl", "thunder-shock", "tail-whip", "thunder-wave"}
+	if !reflect.DeepEqual(pikachu.Moves, want) {
+		t.Errorf("expected moves %v, got %v", want, pikachu.Moves)
+	}
+
+	if _, err := runInput(t, cfg, "battle"); err == nil {
+		t.Error("expected an error without a wild pokemon")
+	}
+	if _, err := runInput(t, cfg, "goto kanto-route-1-area"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := runInput(t, cfg, "walk"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	output, err = runInput(t, cfg, "battle")
+	if err != nil || !strings.Contains(output, "Go, pikachu!") || !strings.Contains(output, " 2. thunder-shock (electric) 30/30 PP") {
+		t.Fatalf("expected the battle to start, got %v:\n%s", err, output)
+	}
+	if _, err := runInput(t, cfg, "walk"); err == nil {
+		t.Error("expected an error when walking away mid-battle")
+	}
+	if _, err := runInput(t, cfg, "attack surf"); err == nil {
+		t.Error("expected an error for an unknown move")
+	}
+
+	// A level 12 pikachu knocks out a level 2-5 bidoof in a few turns
+	for i := 0; cfg.battle != nil; i++ {
+		if i == 10 {
+			t.Fatal("expected the battle to be over")
+		}
+		output, err = runInput(t, cfg, "attack thunder-shock")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !strings.Contains(output, "pikachu used thunder-shock!") {
+			t.Errorf("expected pikachu to attack, got:\n%s", output)
+		}
+	}
+	if !strings.Contains(output, "You defeated the wild bidoof!") || cfg.wild != nil {
+		t.Errorf("expected to win, got:\n%s", output)
+	}
+
+	output, err = runInput(t, cfg, "battle --trainer")
+	if err != nil || !strings.Contains(output, "wants to battle!") {
+		t.Fatalf("expected a trainer battle, got %v:\n%s", err, output)
+	}
+	if _, err := runInput(t, cfg, "catch "+cfg.battle.foe.Species); err == nil {
+		t.Error("expected an error when catching a trainer's pokemon")
+	}
+	output, err = runInput(t, cfg, "run")
+	if err != nil || !strings.HasPrefix(output, "You forfeited the battle") || cfg.battle != nil {
+		t.Errorf("expected to forfeit, got %v: %q", err, output)
+	}
+
+	// The pokemon fighting can't be moved around or changed
+	if _, err := runInput(t, cfg, "walk"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := runInput(t, cfg, "battle"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, input := range []string{"release pikachu", "deposit pikachu", "withdraw pikachu", "swap pikachu pikachu", "evolve pikachu --stone=thunder-stone"} {
+		if _, err := runInput(t, cfg, input); !errors.Is(err, errInBattle) {
+			t.Errorf("%q: expected errInBattle, got %v", input, err)
+		}
+	}
+
+	// A ball that misses gives the foe a turn
+	foe := cfg.wild.Name
+	for escaped := false; !escaped; {
+		if cfg.battle == nil {
+			t.Fatal("expected a throw to fail")
+		}
+		output, err = runInput(t, cfg, "catch "+foe)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		escaped = strings.Contains(output, foe+" escaped!")
+	}
+	if !strings.Contains(output, foe+" used ") {
+		t.Errorf("expected the foe to attack after the escape, got:\n%s", output)
+	}
+}
+
+func TestBattleForm(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	// wormadam-plant has no species of its own, only wormadam does
+	for _, input := range []string{"catch pikachu --free-catch --level=12 --ball=master-ball", "goto sinnoh-route-208-area", "walk"} {
+		if _, err := runInput(t, cfg, input); err != nil {
+			t.Fatalf("%q: expected no error, got %v", input, err)
+		}
+	}
+	output, err := runInput(t, cfg, "battle")
+	if err != nil || !strings.Contains(output, "wormadam-plant Lv.") {
+		t.Fatalf("expected a battle with the wild form, got %v:\n%s", err, output)
+	}
+	if _, err := runInput(t, cfg, "run"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	output, err = runInput(t, cfg, "battle --trainer")
+	if err != nil || !strings.Contains(output, "sent out wormadam-plant") {
+		t.Errorf("expected a trainer with the form, got %v:\n%s", err, output)
+	}
+}
+
+func TestExperienceAndLeveling(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	answers := []string{}
+	cfg.prompt = func(string) (string, error) {
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, nil
+	}
+
+	if _, err := runInput(t, cfg, "catch pikachu --free-catch --level=12 --ball=master-ball"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	pikachu := cfg.storage.Party[0]
+	if pikachu.Exp != 1728 {
+		t.Errorf("expected 1728 exp at level 12, got %d", pikachu.Exp)
+	}
+	output, err := runInput(t, cfg, "inspect pikachu")
+	if err != nil || !strings.Contains(output, "Exp: 1728 [--------------------] 469 to level 13\n") {
+		t.Errorf("expected an empty exp bar, got %v:\n%s", err, output)
+	}
+
+	// Level 13 brings quick-attack, replacing growl
+	answers = []string{"1"}
+	output = captureOutput(t, func() { err = gainExperience(cfg, pikachu, 500) })
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{"pikachu gained 500 Exp. Points!", "pikachu grew to level 13!", "wants to learn quick-attack", "forgot growl and learned quick-attack!"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q, got:\n%s", want, output)
+		}
+	}
+	if pikachu.Level != 13 || pikachu.Exp != 2228 || pikachu.Moves[0] != "quick-attack" {
+		t.Errorf("expected a level 13 pikachu knowing quick-attack, got %+v", pikachu)
+	}
+	output, err = runInput(t, cfg, "inspect pikachu")
+	if err != nil || !strings.Contains(output, "Exp: 2228 [#-------------------] 516 to level 14\n") {
+		t.Errorf("expected a started exp bar, got %v:\n%s", err, output)
+	}
+
+	output, err = runInput(t, cfg, "learn pikachu")
+	if err != nil || !strings.Contains(output, "pikachu can learn: growl") {
+		t.Errorf("expected growl to be learnable again, got %v:\n%s", err, output)
+	}
+	if _, err := runInput(t, cfg, "learn pikachu thunderbolt"); err == nil {
+		t.Error("expected an error for a move not learned by level up")
+	}
+	output, err = runInput(t, cfg, "learn pikachu growl --forget=tail-whip")
+	if err != nil || !slices.Contains(pikachu.Moves, "growl") || slices.Contains(pikachu.Moves, "tail-whip") {
+		t.Errorf("expected growl to replace tail-whip, got %v: %v\n%s", err, pikachu.Moves, output)
+	}
+
+	// Charmander evolves at level 16 unless stopped
+	if _, err := runInput(t, cfg, "catch charmander --free-catch --level=15 --ball=master-ball"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	charmander := cfg.storage.Party[1]
+	answers = []string{"n"}
+	output = captureOutput(t, func() { err = gainExperience(cfg, charmander, 500) })
+	if err != nil || !strings.Contains(output, "Huh? charmander stopped evolving!") || charmander.Species != "charmander" {
+		t.Errorf("expected the evolution to be cancelled, got %v:\n%s", err, output)
+	}
+	cfg.prompt = nil
+	output = captureOutput(t, func() { err = gainExperience(cfg, charmander, 1000) })
+	if err != nil || !strings.Contains(output, "evolved into charmeleon!") || charmander.Species != "charmeleon" {
+		t.Errorf("expected charmander to evolve, got %v:\n%s", err, output)
+	}
+}
+
+func TestShopAndBag(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	cfg.savePath = filepath.Join(t.TempDir(), "save.json")
+
+	output, err := runInput(t, cfg, "shop")
+	if err != nil || !strings.Contains(output, "ultra-ball        ₽800 0\n") || !strings.Contains(output, "Money: ₽3000") {
+		t.Errorf("expected the mart's prices, got %v:\n%s", err, output)
+	}
+	if _, err := runInput(t, cfg, "shop buy ultra-ball 4"); !errors.Is(err, inventory.ErrNotEnoughMoney) {
+		t.Errorf("expected not enough money, got %v", err)
+	}
+	if _, err := runInput(t, cfg, "shop buy master-ball"); err == nil {
+		t.Error("expected an error for an item the mart doesn't sell")
+	}
+	output, err = runInput(t, cfg, "shop buy ultra-ball 2")
+	if err != nil || !strings.Contains(output, "You bought 2 ultra-ball for ₽1600") || cfg.bag.Money != 1400 {
+		t.Errorf("expected to buy 2 ultra-balls, got %v:\n%s", err, output)
+	}
+	output, err = runInput(t, cfg, "shop sell poke-ball 2")
+	if err != nil || !strings.Contains(output, "You sold 2 poke-ball for ₽200") || cfg.bag.Money != 1600 {
+		t.Errorf("expected to sell 2 poke-balls, got %v:\n%s", err, output)
+	}
+
+	output, err = runInput(t, cfg, "bag")
+	if err != nil || !strings.Contains(output, "pokeballs:\n - poke-ball x3\n - ultra-ball x2\n") {
+		t.Errorf("expected the balls in the bag, got %v:\n%s", err, output)
+	}
+
+	// Every throw uses up a ball, caught or not
+	if _, err := runInput(t, cfg, "goto eterna-forest-area"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for cfg.bag.Count("ultra-ball") > 0 {
+		if cfg.wild == nil {
+			if _, err := runInput(t, cfg, "walk"); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+		if _, err := runInput(t, cfg, "catch --ball=ultra-ball"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if cfg.wild == nil {
+		runInput(t, cfg, "walk")
+	}
+	if _, err := runInput(t, cfg, "catch --ball=ultra-ball"); err == nil {
+		t.Error("expected an error without ultra-balls")
+	}
+
+	if err := writeGame(cfg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	restored := newFakeServerConfig(t)
+	restored.savePath = cfg.savePath
+	if err := loadGame(restored); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(restored.bag, cfg.bag) {
+		t.Errorf("expected the bag to round-trip, got %+v, want %+v", restored.bag, cfg.bag)
+	}
+}
+
+func TestPokedexCompletion(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	cfg.savePath = filepath.Join(t.TempDir(), "save.json")
+
+	if _, err := runInput(t, cfg, "explore sinnoh-route-201-area"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := runInput(t, cfg, "catch starly --free-catch --ball=master-ball"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	output, err := runInput(t, cfg, "pokedex --region=sinnoh")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{
+		"original-sinnoh pokedex: 1/16 caught (6%), 2 seen\n",
+		"#010 starly       caught\n",
+		"#012 bidoof       seen\n",
+		"#022 magikarp\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "By generation:") {
+		t.Errorf("expected no generations for a regional dex, got:\n%s", output)
+	}
+
+	output, err = runInput(t, cfg, "pokedex --seen")
+	if err != nil || !strings.Contains(output, "#399 bidoof       seen\n") || strings.Contains(output, "starly") {
+		t.Errorf("expected only bidoof seen, got %v:\n%s", err, output)
+	}
+	if !strings.Contains(output, " - generation-iv (sinnoh): 1/5 caught (20%), 2 seen\n") {
+		t.Errorf("expected the generation iv completion, got:\n%s", output)
+	}
+	output, _ = runInput(t, cfg, "pokedex --missing --dex=kanto")
+	if !strings.Contains(output, "#001 bulbasaur") || strings.Contains(output, " caught\n") {
+		t.Errorf("expected the missing kanto pokemon, got:\n%s", output)
+	}
+	if _, err := runInput(t, cfg, "pokedex --seen --caught"); err == nil {
+		t.Error("expected an error with two filters")
+	}
+
+	if err := writeGame(cfg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	restored := newFakeServerConfig(t)
+	restored.savePath = cfg.savePath
+	if err := loadGame(restored); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(restored.dex, cfg.dex) {
+		t.Errorf("expected the dex to round-trip, got %+v, want %+v", restored.dex, cfg.dex)
+	}
+}
+
+func TestPokedexForms(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	cfg.savePath = filepath.Join(t.TempDir(), "save.json")
+
+	// wormadam-plant is a form of the wormadam species
+	for _, input := range []string{"explore sinnoh-route-208-area", "goto sinnoh-route-208-area", "walk"} {
+		if _, err := runInput(t, cfg, input); err != nil {
+			t.Fatalf("%q: expected no error, got %v", input, err)
+		}
+	}
+	if !reflect.DeepEqual(cfg.dex.Seen, map[string]bool{"wormadam": true}) {
+		t.Errorf("expected wormadam seen, got %v", cfg.dex.Seen)
+	}
+	if _, err := runInput(t, cfg, "catch wormadam-plant --free-catch --ball=master-ball"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	output, _ := runInput(t, cfg, "pokedex --caught")
+	if !strings.Contains(output, "#413 wormadam     caught\n") {
+		t.Errorf("expected wormadam caught, got:\n%s", output)
+	}
+
+	// Saves from before the dex register the species they own
+	cfg.dex = nil
+	if err := writeGame(cfg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	restored := newFakeServerConfig(t)
+	restored.savePath = cfg.savePath
+	if err := loadGame(restored); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if restored.dex.Status("wormadam") != dex.Caught || restored.dex.Status("wormadam-plant") != dex.Unseen {
+		t.Errorf("expected the wormadam species caught, got %+v", restored.dex)
+	}
+
+	// Offline, the save still opens with the pokemon's own name
+	offline := newFailingServerConfig(t, "pokemon")
+	offline.savePath = cfg.savePath
+	output = captureOutput(t, func() {
+		if err := loadGame(offline); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+	if offline.dex.Status("wormadam-plant") != dex.Caught || !strings.Contains(output, "Warning:") {
+		t.Errorf("expected wormadam-plant caught with a warning, got %+v:\n%s", offline.dex, output)
+	}
+}
+
+func TestPokedexQuery(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+	for _, name := range []string{"pikachu", "charmander", "gyarados", "geodude"} {
+		if _, err := runInput(t, cfg, "catch "+name+" --free-catch --ball=master-ball"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	cases := []struct {
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{input: "pokedex --where 'type=electric and speed>50'", expected: []string{"pikachu"}},
+		{input: "pokedex --where 'type=fire or type=flying' --sort=-attack", expected: []string{"gyarados", "charmander"}},
+		{input: "pokedex --where 'not (type=water or gen>1)' --sort speed", expected: []string{"geodude", "charmander", "pikachu"}},
+		{input: "pokedex --sort=-total --limit 2", expected: []string{"gyarados", "pikachu"}},
+		{input: "pokedex --where 'weight>9000'", expected: []string{}},
+		{input: "pokedex --where 'colour=red'", wantErr: true},
+		{input: "pokedex --where 'speed>'", wantErr: true},
+		{input: "pokedex --sort=colour", wantErr: true},
+		{input: "pokedex --limit=0", wantErr: true},
+		{input: "pokedex --where type=electric and speed>200", wantErr: true},
+	}
+	for _, c := range cases {
+		output, err := runInput(t, cfg, c.input)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", c.input, err)
+		}
+		names := []string{}
+		for _, line := range strings.Split(strings.TrimSpace(output), "\n")[1:] {
+			names = append(names, strings.Fields(line)[1])
+		}
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("%s: expected %v, got:\n%s", c.input, c.expected, output)
+		}
+	}
+
+	// One-shot mode gets the words as the shell split them
+	var err error
+	output := captureOutput(t, func() {
+		err = runCommand(cfg, []string{"pokedex", "--where", "type=electric and speed>200"})
+	})
+	if err != nil || output != "No pokemon match\n" {
+		t.Errorf("expected the whole query to apply, got %v:\n%s", err, output)
+	}
+
+	// Fields are checked before anything is fetched, with nothing owned
+	if _, err := runInput(t, newFakeServerConfig(t), "pokedex --where 'colour=red'"); err == nil {
+		t.Error("expected an error for an unknown field without any pokemon")
+	}
+	record := ownedRecord(&owned.Pokemon{}, pokeapi.PokemonDetails{}, 1)
+	fields := slices.Sorted(maps.Keys(record))
+	if !reflect.DeepEqual(fields, slices.Sorted(slices.Values(ownedFields))) {
+		t.Errorf("expected the record fields to be %v, got %v", ownedFields, fields)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	cases := []struct {
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{input: "search pikachu", expected: []string{"pokemon        pikachu\n"}},
+		{input: "search pikchu", expected: []string{"Did you mean pikachu (pokemon)?", "pokemon        pikachu\n"}},
+		{input: "search great ball", expected: []string{"item           great-ball\n"}},
+		{input: "search tackel", expected: []string{"Did you mean tackle (move)?"}},
+		{input: "search route 201", expected: []string{"location-area  sinnoh-route-201-area\n"}},
+		{input: "search static --in=ability", expected: []string{"ability        static\n"}},
+		{input: "search ball --limit 1", expected: []string{"item           poke-ball\n", "...and 4 more\n"}},
+		{input: "search zzz", expected: []string{"No results for zzz\n"}},
+		{input: "search", wantErr: true},
+		{input: "search pikachu --in=berry", wantErr: true},
+	}
+	for _, c := range cases {
+		output, err := runInput(t, cfg, c.input)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", c.input, err)
+		}
+		for _, want := range c.expected {
+			if !strings.Contains(output, want) {
+				t.Errorf("%s: expected %q, got:\n%s", c.input, want, output)
+			}
+		}
+	}
+
+	output, err := runInput(t, cfg, "search tackle --in=move,move")
+	if err != nil || strings.Count(output, "move           tackle\n") != 1 {
+		t.Errorf("expected tackle once, got %v:\n%s", err, output)
+	}
+
+	for input, want := range map[string]string{
+		"catch pikahcu --free-catch":   "there is no pokemon named pikahcu, did you mean pikachu?",
+		"explore sinnoh-route-201":     "there is no location area named sinnoh-route-201, did you mean sinnoh-route-201-area?",
+		"catch missingno --free-catch": "there is no pokemon named missingno",
+	} {
+		if _, err := runInput(t, cfg, input); err == nil || err.Error() != want {
+			t.Errorf("%s: expected %q, got %v", input, want, err)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	cfg := newFakeServerConfig(t)
+
+	output, err := runInput(t, cfg, "compare pikachu gyarados charmander")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{
+		"                pikachu   gyarados      charmander\n",
+		"types           electric  water/flying  fire\n",
+		"special-attack  50        60*           60*\n",
+		"speed           90*       81            65\n",
+		"total           320       540*          309\n",
+		"weight          60        2350          85\n",
+		" - pikachu: static, lightning-rod (hidden)\n",
+		" - pikachu vs gyarados: electric 4x\n",
+		" - charmander vs gyarados: fire 0.5x\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = runInput(t, cfg, "compare pikachu pikachu")
+	if err != nil || strings.Count(output, " - pikachu vs pikachu: electric 0.5x\n") != 2 {
+		t.Errorf("expected both matchups of the same pokemon, got %v:\n%s", err, output)
+	}
+
+	if _, err := runInput(t, cfg, "compare pikachu"); err == nil {
+		t.Error("expected an error with a single pokemon")
+	}
+	_, err = runInput(t, cfg, "compare pikachu pikchu")
+	if err == nil || !strings.Contains(err.Error(), "did you mean pikachu?") {
+		t.Errorf("expected a suggestion, got %v", err)
+	}
+}
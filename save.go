@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/nurusanwe/pokedexcli/internal/dex"
+	"github.com/nurusanwe/pokedexcli/internal/inventory"
+	"github.com/nurusanwe/pokedexcli/internal/owned"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// saveVersion is bumped whenever the save file format changes
+// incompatibly
+const saveVersion = 1
+
+// saveFile is the progress of the trainer kept between runs
+type saveFile struct {
+	Version  int            `json:"version"`
+	Storage  *owned.Storage `json:"storage"`
+	Bag      *inventory.Bag `json:"bag,omitempty"`
+	Dex      *dex.Dex       `json:"dex,omitempty"`
+	Location string         `json:"location,omitempty"`
+	Language string         `json:"language,omitempty"`
+}
+
+// defaultSavePath returns where the save file lives, overridable with
+// POKEDEXCLI_SAVE, or "" when there's no user config directory
+func defaultSavePath() string {
+	if path := os.Getenv("POKEDEXCLI_SAVE"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokedexcli", "save.json")
+}
+
+// loadGame restores the progress saved at cfg.savePath. A missing save
+// file is a new game.
+func loadGame(cfg *config) error {
+	if cfg.savePath == "" {
+		return nil
+	}
+
+	dat, err := os.ReadFile(cfg.savePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	save := saveFile{}
+	if err := json.Unmarshal(dat, &save); err != nil {
+		return fmt.Errorf("invalid save file %s: %w", cfg.savePath, err)
+	}
+	if save.Version != saveVersion {
+		return fmt.Errorf("unsupported save file version %d", save.Version)
+	}
+
+	if save.Storage != nil {
+		cfg.storage = save.Storage
+		// Older saves may have had fewer boxes
+		for len(cfg.storage.Boxes) < owned.BoxCount {
+			cfg.storage.Boxes = append(cfg.storage.Boxes, nil)
+		}
+	}
+	// Saves from before the bag start with a new trainer's bag
+	if save.Bag != nil {
+		cfg.bag = save.Bag
+	}
+	if save.Dex != nil {
+		cfg.dex = save.Dex
+	} else {
+		// Saves from before the dex still register what they own. The
+		// game must open offline too, so a Pokemon whose species can't
+		// be looked up is registered under its own name.
+		names := []string{}
+		for _, p := range cfg.storage.All() {
+			names = append(names, p.Species)
+		}
+		details, err := pokeapi.FetchConcurrently(names, moveWorkers, cfg.pokeapiClient.FetchPokemonDetails)
+		for _, name := range names {
+			if d, ok := details[name]; ok {
+				name = d.Species.Name
+			}
+			cfg.dex.MarkCaught(name)
+		}
+		if err != nil {
+			fmt.Println("Warning: some pokedex entries are listed by pokemon rather than species:", err)
+		}
+	}
+	cfg.location = save.Location
+	if save.Language != "" {
+		cfg.language = save.Language
+	}
+	return nil
+}
+
+// writeGame saves the progress to cfg.savePath. The file is replaced
+// atomically so a crash never leaves a truncated save behind.
+func writeGame(cfg *config) error {
+	if cfg.savePath == "" {
+		return nil
+	}
+
+	save := saveFile{
+		Version:  saveVersion,
+		Storage:  cfg.storage,
+		Bag:      cfg.bag,
+		Dex:      cfg.dex,
+		Location: cfg.location,
+		Language: cfg.language,
+	}
+	dat, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.savePath), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(cfg.savePath), ".save-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(dat); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), cfg.savePath)
+}
+
+// saveGame writes the save file, reporting failures to the user
+func saveGame(cfg *config) {
+	if err := writeGame(cfg); err != nil {
+		fmt.Println("Could not save the game:", err)
+	}
+}
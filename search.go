@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nurusanwe/pokedexcli/internal/fuzzy"
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// searchResources are the resource lists search looks names up in
+var searchResources = []string{"pokemon", "move", "item", "location-area", "ability"}
+
+// searchLimit is how many results search shows by default
+const searchLimit = 10
+
+// resourceNames returns the names of every entry of a resource list.
+// The lists are cached like any other response, so this is an index
+// built once.
+func resourceNames(conf *config, resource string) ([]string, error) {
+	list, err := conf.pokeapiClient.ListResources(resource)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list))
+	for _, r := range list {
+		names = append(names, r.Name)
+	}
+	return names, nil
+}
+
+// suggestNotFound turns a 404 for name in a resource list into an
+// error hinting at the closest name. Other errors are returned as is.
+func suggestNotFound(conf *config, resource, name string, err error) error {
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		return err
+	}
+	kind := strings.ReplaceAll(resource, "-", " ")
+	names, listErr := resourceNames(conf, resource)
+	if listErr != nil {
+		return err
+	}
+	if suggestion, ok := fuzzy.Suggest(name, names); ok {
+		return fmt.Errorf("there is no %s named %s, did you mean %s?", kind, name, suggestion)
+	}
+	return fmt.Errorf("there is no %s named %s", kind, name)
+}
+
+func commandSearch(conf *config, args ...string) error {
+	args, flags := parseValueFlags(args, "in", "limit")
+	text := fuzzy.Normalize(strings.Join(args, " "))
+	if text == "" {
+		return errors.New("usage: search <text> [--in=pokemon,move,...] [--limit n]")
+	}
+
+	resources := searchResources
+	if in, ok := flags["in"]; ok {
+		resources = []string{}
+		for _, r := range strings.Split(in, ",") {
+			if !slices.Contains(searchResources, r) {
+				return fmt.Errorf("can't search %s, expected one of %s", r, strings.Join(searchResources, ", "))
+			}
+			if !slices.Contains(resources, r) {
+				resources = append(resources, r)
+			}
+		}
+	}
+	limit := searchLimit
+	if l, ok := flags["limit"]; ok {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid limit %q", l)
+		}
+		limit = n
+	}
+
+	indexes, err := pokeapi.FetchConcurrently(resources, len(resources), func(resource string) ([]string, error) {
+		return resourceNames(conf, resource)
+	})
+	if err != nil {
+		return err
+	}
+
+	type result struct {
+		resource string
+		match    fuzzy.Match
+	}
+	results := []result{}
+	for _, resource := range resources {
+		for _, m := range fuzzy.Rank(text, indexes[resource]) {
+			results = append(results, result{resource, m})
+		}
+	}
+	// Matches of the same kind and distance keep the order of the
+	// resources, Pokemon first
+	slices.SortStableFunc(results, func(a, b result) int {
+		if a.match.Kind != b.match.Kind {
+			return int(a.match.Kind) - int(b.match.Kind)
+		}
+		return a.match.Distance - b.match.Distance
+	})
+
+	if len(results) == 0 {
+		fmt.Printf("No results for %s\n", text)
+		return nil
+	}
+	if best := results[0]; best.match.Kind == fuzzy.Typo {
+		fmt.Printf("No results for %s. Did you mean %s (%s)?\n", text, best.match.Name, best.resource)
+	}
+	for _, r := range results[:min(limit, len(results))] {
+		fmt.Printf("%-14s %s\n", r.resource, r.match.Name)
+	}
+	if len(results) > limit {
+		fmt.Printf("...and %d more\n", len(results)-limit)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/nurusanwe/pokedexcli/internal/pokeapi"
+)
+
+// martStock is what the Poké Mart sells
+var martStock = []string{"poke-ball", "great-ball", "ultra-ball", "potion", "super-potion", "hyper-potion"}
+
+// bagPockets orders the pockets of the bag as the games do
+var bagPockets = []string{"medicine", "pokeballs", "berries", "battle", "machines", "mail", "misc", "key"}
+
+// itemWorkers bounds the concurrent requests when resolving items
+const itemWorkers = 8
+
+// prizePerLevel is the money won per level of a defeated trainer's
+// Pokemon
+const prizePerLevel = 40
+
+// itemPrice fetches the price of an item. Items without a cost can't
+// be bought or sold.
+func itemPrice(conf *config, name string) (int, error) {
+	item, err := conf.pokeapiClient.FetchItem(name)
+	if err != nil {
+		return 0, err
+	}
+	if item.Cost == 0 {
+		return 0, fmt.Errorf("%s can't be bought or sold", item.Name)
+	}
+	return item.Cost, nil
+}
+
+// parseQuantity reads the optional quantity of a shop command
+func parseQuantity(args []string) (int, error) {
+	if len(args) == 0 {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid quantity %q", args[0])
+	}
+	return n, nil
+}
+
+func commandShop(conf *config, args ...string) error {
+	if len(args) == 0 {
+		items, err := pokeapi.FetchConcurrently(martStock, itemWorkers, conf.pokeapiClient.FetchItem)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Welcome to the Poké Mart!")
+		fmt.Printf("%-14s %7s %s\n", "Item", "Price", "In bag")
+		for _, name := range martStock {
+			fmt.Printf("%-14s %7s %d\n", name, fmt.Sprintf("₽%d", items[name].Cost), conf.bag.Count(name))
+		}
+		fmt.Printf("Money: ₽%d\n", conf.bag.Money)
+		return nil
+	}
+
+	if len(args) < 2 || len(args) > 3 {
+		return errors.New("usage: shop [buy|sell <item> [quantity]]")
+	}
+	action, name := args[0], args[1]
+	n, err := parseQuantity(args[2:])
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "buy":
+		if !slices.Contains(martStock, name) {
+			return fmt.Errorf("the Poké Mart doesn't sell %s", name)
+		}
+		price, err := itemPrice(conf, name)
+		if err != nil {
+			return err
+		}
+		if err := conf.bag.Buy(name, n, price); err != nil {
+			return err
+		}
+		fmt.Printf("You bought %d %s for ₽%d\n", n, name, n*price)
+	case "sell":
+		if conf.bag.Count(name) < n {
+			return fmt.Errorf("you have %d %s", conf.bag.Count(name), name)
+		}
+		price, err := itemPrice(conf, name)
+		if err != nil {
+			return err
+		}
+		earned, err := conf.bag.Sell(name, n, price)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("You sold %d %s for ₽%d\n", n, name, earned)
+	default:
+		return fmt.Errorf("unknown shop action %q, expected buy or sell", action)
+	}
+	fmt.Printf("Money: ₽%d\n", conf.bag.Money)
+	return nil
+}
+
+func commandBag(conf *config, args ...string) error {
+	fmt.Printf("Money: ₽%d\n", conf.bag.Money)
+	names := conf.bag.Names()
+	if len(names) == 0 {
+		fmt.Println("Your bag is empty")
+		return nil
+	}
+
+	items, err := pokeapi.FetchConcurrently(names, itemWorkers, conf.pokeapiClient.FetchItem)
+	if err != nil {
+		return err
+	}
+	pockets := map[string][]string{}
+	for _, name := range names {
+		category, err := conf.pokeapiClient.FetchItemCategory(items[name].Category.Name)
+		if err != nil {
+			return err
+		}
+		pockets[category.Pocket.Name] = append(pockets[category.Pocket.Name], name)
+	}
+
+	for _, pocket := range bagPockets {
+		if len(pockets[pocket]) == 0 {
+			continue
+		}
+		fmt.Printf("%s:\n", pocket)
+		for _, name := range pockets[pocket] {
+			fmt.Printf(" - %s x%d\n", name, conf.bag.Count(name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nurusanwe/pokedexcli/internal/typechart"
+)
+
+// loadTypeChart fetches every type the first time it is needed and
+// keeps the resulting chart for the rest of the session
+func loadTypeChart(conf *config) (*typechart.Chart, error) {
+	if conf.typeChart != nil {
+		return conf.typeChart, nil
+	}
+
+	types, err := conf.pokeapiClient.FetchAllTypes()
+	if err != nil {
+		return nil, err
+	}
+	conf.typeChart = typechart.New(types)
+	return conf.typeChart, nil
+}
+
+// formatMultiplier renders a damage multiplier the way the games' charts do
+func formatMultiplier(m float64) string {
+	switch m {
+	case 0:
+		return "0"
+	case 0.25:
+		return "¼"
+	case 0.5:
+		return "½"
+	case 1:
+		return "·"
+	}
+	return fmt.Sprintf("%g", m)
+}
+
+// sortedMultipliers lists types by decreasing distance from neutral
+// damage, then by name, e.g. "ground (4x), rock (2x)"
+func sortedMultipliers(m map[string]float64) string {
+	types := make([]string, 0, len(m))
+	for t := range m {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		di, dj := m[types[i]], m[types[j]]
+		if di < 1 {
+			di, dj = 1/di, 1/dj
+		}
+		if di != dj {
+			return di > dj
+		}
+		return types[i] < types[j]
+	})
+
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, fmt.Sprintf("%s (%gx)", t, m[t]))
+	}
+	return strings.Join(parts, ", ")
+}